@@ -0,0 +1,265 @@
+package address
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/checksum0/go-cryptoutils/base58"
+	"github.com/checksum0/go-cryptoutils/cashaddr"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// HashSize is the size of the RIPEMD160(SHA256) hashes held by P2PKH and P2SH
+// addresses.
+const HashSize = 20
+
+var (
+	// ErrInvalidHashSize ...
+	ErrInvalidHashSize = errors.New("address hash must be 20 bytes")
+
+	// ErrUnknownNetwork ...
+	ErrUnknownNetwork = errors.New("address belongs to no registered network")
+
+	// ErrWrongNetwork ...
+	ErrWrongNetwork = errors.New("address belongs to another network")
+
+	// ErrUnsupportedType ...
+	ErrUnsupportedType = errors.New("unsupported cashaddr type")
+)
+
+// Address is a P2PKH or P2SH address of a network.
+type Address interface {
+	// Hash returns the 20-byte hash the address pays to.
+	Hash() []byte
+
+	// Params returns the network of the address. Networks sharing address
+	// bytes or prefixes, such as the test networks, are reported as the first
+	// of them in registration order.
+	Params() *bchcfg.Params
+
+	// Legacy returns the base58check form of the address.
+	Legacy() string
+
+	// CashAddr returns the cashaddr form of the address, with its prefix.
+	CashAddr() string
+
+	// String returns the cashaddr form of the address.
+	String() string
+
+	// Script returns the output script paying to the address.
+	Script() []byte
+}
+
+// P2PKH is a pay-to-pubkey-hash address.
+type P2PKH struct {
+	hash   [HashSize]byte
+	params *bchcfg.Params
+}
+
+// P2SH is a pay-to-script-hash address.
+type P2SH struct {
+	hash   [HashSize]byte
+	params *bchcfg.Params
+}
+
+// NewP2PKH returns the P2PKH address of the public key hash on the network.
+func NewP2PKH(hash []byte, params *bchcfg.Params) (*P2PKH, error) {
+	if len(hash) != HashSize {
+		return nil, ErrInvalidHashSize
+	}
+
+	a := &P2PKH{params: params}
+	copy(a.hash[:], hash)
+
+	return a, nil
+}
+
+// NewP2SH returns the P2SH address of the script hash on the network.
+func NewP2SH(hash []byte, params *bchcfg.Params) (*P2SH, error) {
+	if len(hash) != HashSize {
+		return nil, ErrInvalidHashSize
+	}
+
+	a := &P2SH{params: params}
+	copy(a.hash[:], hash)
+
+	return a, nil
+}
+
+// Hash ...
+func (a *P2PKH) Hash() []byte {
+	return append([]byte(nil), a.hash[:]...)
+}
+
+// Params ...
+func (a *P2PKH) Params() *bchcfg.Params {
+	return a.params
+}
+
+// Legacy ...
+func (a *P2PKH) Legacy() string {
+	return base58.CheckEncode(a.hash[:], a.params.LegacyP2PKHAddrID)
+}
+
+// CashAddr ...
+func (a *P2PKH) CashAddr() string {
+	return encodeCashAddr(a.params, cashaddr.P2PKH, a.hash[:])
+}
+
+// String ...
+func (a *P2PKH) String() string {
+	return a.CashAddr()
+}
+
+// Script returns OP_DUP OP_HASH160 <hash> OP_EQUALVERIFY OP_CHECKSIG.
+func (a *P2PKH) Script() []byte {
+	script := make([]byte, 0, 25)
+	script = append(script, 0x76, 0xa9, HashSize)
+	script = append(script, a.hash[:]...)
+
+	return append(script, 0x88, 0xac)
+}
+
+// Hash ...
+func (a *P2SH) Hash() []byte {
+	return append([]byte(nil), a.hash[:]...)
+}
+
+// Params ...
+func (a *P2SH) Params() *bchcfg.Params {
+	return a.params
+}
+
+// Legacy ...
+func (a *P2SH) Legacy() string {
+	return base58.CheckEncode(a.hash[:], a.params.LegacyP2SHAddrID)
+}
+
+// CashAddr ...
+func (a *P2SH) CashAddr() string {
+	return encodeCashAddr(a.params, cashaddr.P2SH, a.hash[:])
+}
+
+// String ...
+func (a *P2SH) String() string {
+	return a.CashAddr()
+}
+
+// Script returns OP_HASH160 <hash> OP_EQUAL.
+func (a *P2SH) Script() []byte {
+	script := make([]byte, 0, 23)
+	script = append(script, 0xa9, HashSize)
+	script = append(script, a.hash[:]...)
+
+	return append(script, 0x87)
+}
+
+func encodeCashAddr(params *bchcfg.Params, typ cashaddr.Type, hash []byte) string {
+	// The hash size and type are valid, so only a malformed network prefix
+	// can fail, which Params.Validate does not check.
+	address, err := cashaddr.Encode(strings.TrimSuffix(params.CashAddressPrefix, ":"), typ, hash)
+	if err != nil {
+		return ""
+	}
+
+	return address
+}
+
+// Decode decodes a legacy base58check or cashaddr address of a network of the
+// default bchcfg registry. See DecodeRegistry.
+func Decode(address string) (Address, error) {
+	return DecodeRegistry(address, bchcfg.DefaultRegistry())
+}
+
+// DecodeRegistry decodes a legacy base58check or cashaddr address, with or
+// without its prefix, and looks its network up in the registry by address
+// byte or prefix.
+func DecodeRegistry(address string, registry *bchcfg.Registry) (Address, error) {
+	var legacyErr error
+	if !strings.Contains(address, ":") {
+		payload, version, err := base58.CheckDecode(address)
+		if err == nil {
+			return decodeLegacy(payload, version, registry)
+		}
+		legacyErr = err
+	}
+
+	prefix, typ, hash, err := cashaddr.DecodeRegistry(address, registry)
+	if err != nil {
+		// Base58 strings mixing cases are legacy addresses with a typo.
+		if legacyErr != nil && err == cashaddr.ErrMixedCase {
+			return nil, legacyErr
+		}
+		return nil, err
+	}
+
+	nets, err := registry.ParamsByCashAddressPrefix(prefix)
+	if err != nil {
+		return nil, ErrUnknownNetwork
+	}
+
+	return newAddress(typ, hash, nets[0])
+}
+
+func decodeLegacy(payload []byte, version byte, registry *bchcfg.Registry) (Address, error) {
+	if nets, err := registry.ParamsByP2PKHAddrID(version); err == nil {
+		return newAddress(cashaddr.P2PKH, payload, nets[0])
+	}
+	if nets, err := registry.ParamsByP2SHAddrID(version); err == nil {
+		return newAddress(cashaddr.P2SH, payload, nets[0])
+	}
+
+	return nil, ErrUnknownNetwork
+}
+
+// newAddress returns the address of the hash with the given cashaddr type. It
+// returns a nil Address rather than a nil *P2PKH or *P2SH on error.
+func newAddress(typ cashaddr.Type, hash []byte, params *bchcfg.Params) (Address, error) {
+	if len(hash) != HashSize {
+		return nil, ErrInvalidHashSize
+	}
+
+	switch typ {
+	case cashaddr.P2PKH:
+		return NewP2PKH(hash, params)
+	case cashaddr.P2SH:
+		return NewP2SH(hash, params)
+	default:
+		return nil, ErrUnsupportedType
+	}
+}
+
+// DecodeForNet decodes a legacy base58check or cashaddr address of the given
+// network, and fails with ErrWrongNetwork if the address byte or prefix is
+// that of another network. An address without prefix whose checksum does not
+// match the prefix of the network is reported as of another network as well.
+func DecodeForNet(address string, params *bchcfg.Params) (Address, error) {
+	var legacyErr error
+	if !strings.Contains(address, ":") {
+		payload, version, err := base58.CheckDecode(address)
+		if err == nil {
+			switch version {
+			case params.LegacyP2PKHAddrID:
+				return newAddress(cashaddr.P2PKH, payload, params)
+			case params.LegacyP2SHAddrID:
+				return newAddress(cashaddr.P2SH, payload, params)
+			}
+			return nil, ErrWrongNetwork
+		}
+		legacyErr = err
+	}
+
+	typ, hash, err := cashaddr.DecodePrefix(address, params.CashAddressPrefix)
+	switch {
+	case err == cashaddr.ErrPrefixMismatch:
+		return nil, ErrWrongNetwork
+	case err == cashaddr.ErrInvalidChecksum && legacyErr != nil:
+		return nil, ErrWrongNetwork
+	case err == cashaddr.ErrMixedCase && legacyErr != nil:
+		return nil, legacyErr
+	case err != nil:
+		return nil, err
+	}
+
+	return newAddress(typ, hash, params)
+}
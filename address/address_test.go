@@ -0,0 +1,144 @@
+package address_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/address"
+	"github.com/checksum0/go-cryptoutils/base58"
+	"github.com/checksum0/go-cryptoutils/cashaddr"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestDecode verifies that addresses decode from both legacy and cashaddr
+// forms, with their network, re-encodings and output script, using the
+// translation examples of the cashaddr specification.
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		legacy   string
+		cashAddr string
+		params   *bchcfg.Params
+		script   string
+	}{
+		{
+			"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu",
+			"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a",
+			&bchcfg.MainnetParams,
+			"76a91476a04053bda0a88bda5177b86a15c3b29f55987388ac",
+		},
+		{
+			"1KXrWXciRDZUpQwQmuM1DbwsKDLYAYsVLR",
+			"bitcoincash:qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy",
+			&bchcfg.MainnetParams,
+			"76a914cb481232299cd5743151ac4b2d63ae198e7bb0a988ac",
+		},
+		{
+			"3CWFddi6m4ndiGyKqzYvsFYagqDLPVMTzC",
+			"bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq",
+			&bchcfg.MainnetParams,
+			"a91476a04053bda0a88bda5177b86a15c3b29f55987387",
+		},
+		{
+			"31nwvkZwyPdgzjBJZXfDmSWsC4ZLKpYyUw",
+			"bitcoincash:pqq3728yw0y47sqn6l2na30mcw6zm78dzq5ucqzc37",
+			&bchcfg.MainnetParams,
+			"a914011f28e473c95f4013d7d53ec5fbc3b42df8ed1087",
+		},
+		{
+			"mrLC19Je2BuWQDkWSTriGYPyQJXKkkBmCx",
+			"bchtest:qpm2qsznhks23z7629mms6s4cwef74vcwvqcw003ap",
+			&bchcfg.Testnet3Params,
+			"76a91476a04053bda0a88bda5177b86a15c3b29f55987388ac",
+		},
+	}
+
+	for _, test := range tests {
+		for _, in := range []string{test.legacy, test.cashAddr, test.cashAddr[len(test.params.CashAddressPrefix)+1:]} {
+			a, err := address.Decode(in)
+			if err != nil {
+				t.Errorf("Decode(%s) = err %v", in, err)
+				continue
+			}
+
+			if a.Params() != test.params {
+				t.Errorf("Decode(%s) = network %s (want: %s)", in, a.Params().Name, test.params.Name)
+			}
+			if a.Legacy() != test.legacy {
+				t.Errorf("Decode(%s).Legacy() = %s (want: %s)", in, a.Legacy(), test.legacy)
+			}
+			if a.CashAddr() != test.cashAddr || a.String() != test.cashAddr {
+				t.Errorf("Decode(%s).CashAddr() = %s (want: %s)", in, a.CashAddr(), test.cashAddr)
+			}
+			if script := hex.EncodeToString(a.Script()); script != test.script {
+				t.Errorf("Decode(%s).Script() = %s (want: %s)", in, script, test.script)
+			}
+		}
+	}
+}
+
+// TestDecodeErrors verifies the errors of malformed addresses and addresses of
+// unknown or unexpected networks.
+func TestDecodeErrors(t *testing.T) {
+	hash, _ := hex.DecodeString("76a04053bda0a88bda5177b86a15c3b29f559873")
+	unknownVersion := base58.CheckEncode(hash, 0x30)
+	shortHash := base58.CheckEncode(hash[:19], 0x00)
+	tokenAware, _ := cashaddr.Encode("bitcoincash", cashaddr.TokenP2PKH, hash)
+	p2sh32, _ := cashaddr.Encode("bitcoincash", cashaddr.P2SH, make([]byte, 32))
+	unknownPrefix, _ := cashaddr.Encode("ecash", cashaddr.P2PKH, hash)
+
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{unknownVersion, address.ErrUnknownNetwork},
+		{shortHash, address.ErrInvalidHashSize},
+		{tokenAware, address.ErrUnsupportedType},
+		{p2sh32, address.ErrInvalidHashSize},
+		{unknownPrefix, address.ErrUnknownNetwork},
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggv", base58.ErrChecksum},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q", cashaddr.ErrUnknownPrefix},
+	}
+
+	for _, test := range tests {
+		if a, err := address.Decode(test.in); err != test.err || a != nil {
+			t.Errorf("Decode(%s) = %v, err %v (want: nil, %v)", test.in, a, err, test.err)
+		}
+	}
+
+	// DecodeForNet compares the address with the network directly, so the
+	// network needs to be neither registered nor valid.
+	unregistered := bchcfg.MainnetParams
+	unregistered.Name = ""
+
+	netTests := []struct {
+		in     string
+		params *bchcfg.Params
+		err    error
+	}{
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", &bchcfg.MainnetParams, nil},
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", &bchcfg.Testnet4Params, address.ErrWrongNetwork},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", &bchcfg.MainnetParams, nil},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", &bchcfg.RegTestnetParams, address.ErrWrongNetwork},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", &bchcfg.RegTestnetParams, address.ErrWrongNetwork},
+		{"mrLC19Je2BuWQDkWSTriGYPyQJXKkkBmCx", &bchcfg.Testnet4Params, nil},
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu", &unregistered, nil},
+		{"BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A", &unregistered, nil},
+		{"1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggv", &bchcfg.MainnetParams, base58.ErrChecksum},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q", &bchcfg.MainnetParams, cashaddr.ErrInvalidChecksum},
+	}
+
+	for _, test := range netTests {
+		a, err := address.DecodeForNet(test.in, test.params)
+		if err != test.err {
+			t.Errorf("DecodeForNet(%s, %s) = err %v (want: %v)", test.in, test.params.Name, err, test.err)
+			continue
+		}
+		if err == nil && a.Params() != test.params {
+			t.Errorf("DecodeForNet(%s, %s) = network %s", test.in, test.params.Name, a.Params().Name)
+		}
+	}
+
+	if _, err := address.NewP2SH(hash[:10], &bchcfg.MainnetParams); err != address.ErrInvalidHashSize {
+		t.Errorf("NewP2SH(10 bytes) = err %v (want: %v)", err, address.ErrInvalidHashSize)
+	}
+}
@@ -1,43 +1,68 @@
-// AUTOGENERATED by genalphabet.go, do not edit.
-
 package base58
 
-const (
-	alphabet     = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
-	alphabetIDx0 = '1'
+import (
+	"errors"
 )
 
-var base58 = [256]byte{
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 0, 1, 2, 3, 4, 5, 6,
-	7, 8, 255, 255, 255, 255, 255, 255,
-	255, 9, 10, 11, 12, 13, 14, 15,
-	16, 255, 17, 18, 19, 20, 21, 255,
-	22, 23, 24, 25, 26, 27, 28, 29,
-	30, 31, 32, 255, 255, 255, 255, 255,
-	255, 33, 34, 35, 36, 37, 38, 39,
-	40, 41, 42, 43, 255, 44, 45, 46,
-	47, 48, 49, 50, 51, 52, 53, 54,
-	55, 56, 57, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
-	255, 255, 255, 255, 255, 255, 255, 255,
+// ErrInvalidAlphabet is returned by NewAlphabet when the alphabet is not made
+// of 58 distinct ASCII characters.
+var ErrInvalidAlphabet = errors.New("base58 alphabet must be 58 distinct ASCII characters")
+
+// Alphabet is a base58 alphabet along with its decoding table and the checksum
+// of its check encodings. Its first character encodes leading zero bytes.
+type Alphabet struct {
+	encode   [58]byte
+	decode   [256]byte
+	checksum Checksum // nil means SHA256d
+}
+
+// Predefined alphabets.
+var (
+	// BitcoinAlphabet ...
+	BitcoinAlphabet = mustAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// RippleAlphabet ...
+	RippleAlphabet = mustAlphabet("rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+
+	// FlickrAlphabet ...
+	FlickrAlphabet = mustAlphabet("123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
+)
+
+// NewAlphabet returns the alphabet whose digits, in increasing value, are the
+// characters of s.
+func NewAlphabet(s string) (*Alphabet, error) {
+	if len(s) != 58 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	a := new(Alphabet)
+	for i := range a.decode {
+		a.decode[i] = 255
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 0x80 || a.decode[c] != 255 {
+			return nil, ErrInvalidAlphabet
+		}
+
+		a.encode[i] = c
+		a.decode[c] = byte(i)
+	}
+
+	return a, nil
+}
+
+func mustAlphabet(s string) *Alphabet {
+	a, err := NewAlphabet(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// String returns the characters of the alphabet.
+func (a *Alphabet) String() string {
+	return string(a.encode[:])
 }
@@ -0,0 +1,94 @@
+package base58_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/base58"
+)
+
+// TestNewAlphabet verifies that NewAlphabet rejects alphabets that are not 58
+// distinct ASCII characters.
+func TestNewAlphabet(t *testing.T) {
+	bitcoin := base58.BitcoinAlphabet.String()
+
+	tests := []struct {
+		name string
+		in   string
+		err  error
+	}{
+		{"bitcoin", bitcoin, nil},
+		{"ripple", base58.RippleAlphabet.String(), nil},
+		{"flickr", base58.FlickrAlphabet.String(), nil},
+		{"short", bitcoin[1:], base58.ErrInvalidAlphabet},
+		{"long", bitcoin + "0", base58.ErrInvalidAlphabet},
+		{"duplicate", "2" + bitcoin[1:], base58.ErrInvalidAlphabet},
+		{"non-ascii", "\xff" + bitcoin[1:], base58.ErrInvalidAlphabet},
+	}
+
+	for _, test := range tests {
+		a, err := base58.NewAlphabet(test.in)
+		if err != test.err {
+			t.Errorf("NewAlphabet(%s) = err %v (want: %v)", test.name, err, test.err)
+			continue
+		}
+		if err == nil && a.String() != test.in {
+			t.Errorf("NewAlphabet(%s).String() = %s (want: %s)", test.name, a.String(), test.in)
+		}
+	}
+}
+
+// TestAlphabets verifies that the Ripple and Flickr alphabets encode the same
+// digits as the Bitcoin alphabet, and the Ripple account vectors.
+func TestAlphabets(t *testing.T) {
+	bitcoin := base58.BitcoinAlphabet.String()
+
+	for _, a := range []*base58.Alphabet{base58.RippleAlphabet, base58.FlickrAlphabet} {
+		translate := func(s string) string {
+			return strings.Map(func(r rune) rune {
+				return rune(a.String()[strings.IndexRune(bitcoin, r)])
+			}, s)
+		}
+
+		for x, test := range hexTests {
+			b, _ := hex.DecodeString(test.in)
+			want := translate(test.out)
+
+			if result := a.Encode(b); result != want {
+				t.Errorf("%s Encode(%d) = %s (want: %s)", a, x, result, want)
+			}
+			if result := a.Decode(want); !bytes.Equal(result, b) {
+				t.Errorf("%s Decode(%d) = %x (want: %s)", a, x, result, test.in)
+			}
+		}
+	}
+
+	// Ripple ACCOUNT_ZERO and ACCOUNT_ONE.
+	rippleTests := []struct {
+		in  string
+		out string
+	}{
+		{"0000000000000000000000000000000000000000", "rrrrrrrrrrrrrrrrrrrrrhoLvTp"},
+		{"0000000000000000000000000000000000000001", "rrrrrrrrrrrrrrrrrrrrBZbvji"},
+	}
+
+	for x, test := range rippleTests {
+		b, _ := hex.DecodeString(test.in)
+		if result := base58.RippleAlphabet.CheckEncode(b, 0); result != test.out {
+			t.Errorf("Ripple CheckEncode(%d) = %s (want: %s)", x, result, test.out)
+		}
+
+		result, version, err := base58.RippleAlphabet.CheckDecode(test.out)
+		if err != nil || version != 0 || !bytes.Equal(result, b) {
+			t.Errorf("Ripple CheckDecode(%d) = %x, %d, %v (want: %s, 0, nil)", x, result, version, err, test.in)
+		}
+	}
+
+	// '1' is a Ripple digit but '0' and 'l' are not.
+	if _, err := base58.RippleAlphabet.DecodeString("r1l"); !errors.Is(err, base58.ErrInvalidCharacter) {
+		t.Errorf("Ripple DecodeString(r1l) = err %v (want: %v)", err, base58.ErrInvalidCharacter)
+	}
+}
@@ -1,75 +1,211 @@
 package base58
 
 import (
-	"math/big"
+	"errors"
+	"fmt"
 )
 
-//go:generate go run genalphabet.go
+// ErrInvalidCharacter is returned when decoding a string holding a character
+// outside of the base58 alphabet.
+var ErrInvalidCharacter = errors.New("invalid base58 character")
 
-var bigRadix = big.NewInt(58)
-var bigZero = big.NewInt(0)
+// InvalidCharacterError describes a character outside of the base58 alphabet
+// and its byte offset in the decoded string. It matches ErrInvalidCharacter
+// with errors.Is.
+type InvalidCharacterError struct {
+	Char   byte
+	Offset int
+}
+
+func (e InvalidCharacterError) Error() string {
+	return fmt.Sprintf("%v %q at offset %d", ErrInvalidCharacter, e.Char, e.Offset)
+}
+
+// Is reports whether target is ErrInvalidCharacter.
+func (e InvalidCharacterError) Is(target error) bool {
+	return target == ErrInvalidCharacter
+}
 
-// Decode decodes a base58 string to a byte slice.
+// The codec converts between base 256 and base 58 digit by digit over a byte
+// array, as Bitcoin Core does, instead of dividing a big.Int. Each input digit
+// is multiplied into the digits converted so far, which is quadratic in the
+// input length but allocation free and much faster on the short inputs
+// base58 is used for.
+
+// Decode decodes a base58 string to a byte slice using the Bitcoin alphabet.
+// It returns an empty slice if the string holds a character outside of the
+// alphabet; use DecodeString to tell that apart from decoding the empty string.
 func Decode(b string) []byte {
-	var numZeroes int
+	return BitcoinAlphabet.Decode(b)
+}
+
+// DecodeString decodes a base58 string to a byte slice using the Bitcoin
+// alphabet. It returns an InvalidCharacterError if the string holds a
+// character outside of the alphabet.
+func DecodeString(s string) ([]byte, error) {
+	return BitcoinAlphabet.DecodeString(s)
+}
+
+// Encode encodes a byte slice to a base58 string using the Bitcoin alphabet.
+func Encode(b []byte) string {
+	return BitcoinAlphabet.Encode(b)
+}
 
-	answer := big.NewInt(0)
-	j := big.NewInt(1)
-	scratch := new(big.Int)
+// AppendEncode appends the encoding of src in the Bitcoin alphabet to dst. See
+// Alphabet.AppendEncode.
+func AppendEncode(dst, src []byte) []byte {
+	return BitcoinAlphabet.AppendEncode(dst, src)
+}
 
-	for i := len(b) - 1; i >= 0; i-- {
-		temp := base58[b[i]]
+// AppendDecode appends the decoding of src in the Bitcoin alphabet to dst. See
+// Alphabet.AppendDecode.
+func AppendDecode(dst []byte, src string) ([]byte, error) {
+	return BitcoinAlphabet.AppendDecode(dst, src)
+}
 
-		if temp == 255 {
-			return []byte("")
-		}
+// Decode decodes a base58 string to a byte slice. It returns an empty slice if
+// the string holds a character outside of the alphabet.
+func (a *Alphabet) Decode(b string) []byte {
+	decoded, err := a.DecodeString(b)
+	if err != nil {
+		return []byte("")
+	}
+
+	return decoded
+}
+
+// DecodeString decodes a base58 string to a byte slice. It returns an
+// InvalidCharacterError if the string holds a character outside of the
+// alphabet.
+func (a *Alphabet) DecodeString(s string) ([]byte, error) {
+	return a.AppendDecode(nil, s)
+}
 
-		scratch.SetInt64(int64(temp))
-		scratch.Mul(j, scratch)
+// Encode encodes a byte slice to a base58 string.
+func (a *Alphabet) Encode(b []byte) string {
+	return string(a.AppendEncode(make([]byte, 0, EncodedMaxLen(len(b))), b))
+}
 
-		answer.Add(answer, scratch)
+// EncodedMaxLen returns the maximum length of the encoding of n bytes.
+func EncodedMaxLen(n int) int {
+	return n*138/100 + 1
+}
+
+// DecodedMaxLen returns the maximum length of the decoding of n characters.
+// Leading zero characters decode to one zero byte each, so the bound is n itself.
+func DecodedMaxLen(n int) int {
+	return n
+}
 
-		j.Mul(j, bigRadix)
+// digitsLen returns the length of the base 256 digit buffer needed to decode n
+// characters other than leading zero characters.
+func digitsLen(n int) int {
+	if n == 0 {
+		return 0
 	}
 
-	tempVal := answer.Bytes()
+	return n*733/1000 + 1
+}
+
+// AppendEncode appends the base58 encoding of src to dst and returns the
+// extended buffer. It does not allocate if dst has a capacity of at least
+// len(dst)+EncodedMaxLen(len(src)).
+func (a *Alphabet) AppendEncode(dst, src []byte) []byte {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
 
-	for numZeroes = 0; numZeroes < len(b); numZeroes++ {
-		if b[numZeroes] != alphabetIDx0 {
-			break
+	// The digits are computed big endian at the end of the output area, then
+	// moved right after the leading zero characters while being mapped to the alphabet.
+	start := len(dst)
+	size := EncodedMaxLen(len(src) - zeros)
+	dst = grow(dst, zeros+size)
+	out := dst[start:]
+	digits := out[zeros:]
+
+	length := 0
+	for _, b := range src[zeros:] {
+		carry := uint32(b)
+		i := 0
+		for j := size - 1; (carry != 0 || i < length) && j >= 0; j-- {
+			carry += 256 * uint32(digits[j])
+			digits[j] = byte(carry % 58)
+			carry /= 58
+			i++
 		}
+		length = i
+	}
+
+	for i := 0; i < zeros; i++ {
+		out[i] = a.encode[0]
 	}
 
-	value := make([]byte, (numZeroes + len(tempVal)))
-	copy(value[numZeroes:], tempVal)
+	n := zeros
+	for _, digit := range digits[size-length:] {
+		out[n] = a.encode[digit]
+		n++
+	}
 
-	return value
+	return dst[:start+n]
 }
 
-// Encode encodes a byte slice to a base58 string.
-func Encode(b []byte) string {
-	x := new(big.Int)
-	x.SetBytes(b)
+// AppendDecode appends the decoding of the base58 string src to dst and
+// returns the extended buffer. It does not allocate if dst has a capacity of
+// at least len(dst)+DecodedMaxLen(len(src)). On an invalid character, dst is
+// returned unchanged along with an InvalidCharacterError.
+func (a *Alphabet) AppendDecode(dst []byte, src string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == a.encode[0] {
+		zeros++
+	}
 
-	answer := make([]byte, 0, len(b)*136/100)
+	start := len(dst)
+	size := digitsLen(len(src) - zeros)
+	extended := grow(dst, zeros+size)
+	out := extended[start:]
+	digits := out[zeros:]
+
+	length := 0
+	for i := zeros; i < len(src); i++ {
+		carry := uint32(a.decode[src[i]])
+		if carry == 255 {
+			return dst, InvalidCharacterError{Char: src[i], Offset: i}
+		}
 
-	for x.Cmp(bigZero) > 0 {
-		mod := new(big.Int)
-		x.DivMod(x, bigRadix, mod)
+		k := 0
+		for j := size - 1; (carry != 0 || k < length) && j >= 0; j-- {
+			carry += 58 * uint32(digits[j])
+			digits[j] = byte(carry)
+			carry >>= 8
+			k++
+		}
+		length = k
+	}
 
-		answer = append(answer, alphabet[mod.Int64()])
+	for i := 0; i < zeros; i++ {
+		out[i] = 0
 	}
+	copy(out[zeros:], digits[size-length:])
 
-	for _, i := range b {
-		if i != 0 {
-			break
-		}
-		answer = append(answer, alphabetIDx0)
+	return extended[:start+zeros+length], nil
+}
+
+// grow extends b by n zeroed bytes, reallocating only if its capacity is too
+// small.
+func grow(b []byte, n int) []byte {
+	total := len(b) + n
+	if total > cap(b) {
+		grown := make([]byte, total)
+		copy(grown, b)
+		return grown
 	}
 
-	for i := 0; i < (len(answer))/2; i++ {
-		answer[i], answer[len(answer)-1-i] = answer[len(answer)-1-i], answer[i]
+	b = b[:total]
+	tail := b[total-n:]
+	for i := range tail {
+		tail[i] = 0
 	}
 
-	return string(answer)
+	return b
 }
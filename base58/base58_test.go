@@ -3,6 +3,9 @@ package base58_test
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
+	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/checksum0/go-cryptoutils/base58"
@@ -89,3 +92,208 @@ func TestBase58(t *testing.T) {
 		}
 	}
 }
+
+// TestDecodeString verifies that DecodeString reports the first invalid
+// character and its offset.
+func TestDecodeString(t *testing.T) {
+	tests := []struct {
+		in     string
+		char   byte
+		offset int
+	}{
+		{"0", '0', 0},
+		{"3mJr0", '0', 4},
+		{"O3yxU", 'O', 0},
+		{"3sNI", 'I', 3},
+		{"4kl8", 'l', 2},
+		{"11l", 'l', 2},
+		{"2g\x00", 0, 2},
+	}
+
+	for x, test := range tests {
+		_, err := base58.DecodeString(test.in)
+		want := base58.InvalidCharacterError{Char: test.char, Offset: test.offset}
+		if err != want {
+			t.Errorf("DecodeString(%d) = err %v (want: %v)", x, err, want)
+			continue
+		}
+		if !errors.Is(err, base58.ErrInvalidCharacter) {
+			t.Errorf("DecodeString(%d) = err %v, not ErrInvalidCharacter", x, err)
+		}
+	}
+
+	if b, err := base58.DecodeString(""); err != nil || len(b) != 0 {
+		t.Errorf("DecodeString(\"\") = %x, %v (want: empty, nil)", b, err)
+	}
+}
+
+// TestAppend verifies that AppendEncode and AppendDecode extend the caller
+// buffer, allocate nothing when it is large enough, and leave it untouched on
+// invalid input.
+func TestAppend(t *testing.T) {
+	prefix := []byte("prefix:")
+
+	for x, test := range hexTests {
+		b, _ := hex.DecodeString(test.in)
+
+		encoded := base58.AppendEncode(append([]byte(nil), prefix...), b)
+		if want := string(prefix) + test.out; string(encoded) != want {
+			t.Errorf("AppendEncode(%d) = %q (want: %q)", x, encoded, want)
+		}
+
+		decoded, err := base58.AppendDecode(append([]byte(nil), prefix...), test.out)
+		if err != nil || !bytes.Equal(decoded, append(append([]byte(nil), prefix...), b...)) {
+			t.Errorf("AppendDecode(%d) = %x, %v (want: %x)", x, decoded, err, b)
+		}
+	}
+
+	for x, test := range invalidStringTests {
+		decoded, err := base58.AppendDecode(prefix, test.in)
+		if !errors.Is(err, base58.ErrInvalidCharacter) || !bytes.Equal(decoded, prefix) {
+			t.Errorf("AppendDecode(%d) invalidString = %q, %v (want: %q, %v)", x, decoded, err, prefix, base58.ErrInvalidCharacter)
+		}
+	}
+
+	src, _ := hex.DecodeString(hexTests[4].in)
+	encodeBuf := make([]byte, 0, base58.EncodedMaxLen(len(src)))
+	decodeBuf := make([]byte, 0, base58.DecodedMaxLen(len(hexTests[4].out)))
+	allocs := testing.AllocsPerRun(100, func() {
+		base58.AppendEncode(encodeBuf, src)
+		base58.AppendDecode(decodeBuf, hexTests[4].out)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendEncode/AppendDecode allocated %v times (want: 0)", allocs)
+	}
+}
+
+// TestBigIntEquivalence verifies that the codec matches the former math/big
+// implementation on random inputs, including ones with leading zeros.
+func TestBigIntEquivalence(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		b := make([]byte, rng.Intn(80))
+		rng.Read(b)
+		for j := 0; j < len(b) && rng.Intn(4) == 0; j++ {
+			b[j] = 0
+		}
+
+		encoded := base58.Encode(b)
+		if want := bigEncode(b); encoded != want {
+			t.Fatalf("Encode(%x) = %s (want: %s)", b, encoded, want)
+		}
+		if decoded := base58.Decode(encoded); !bytes.Equal(decoded, bigDecode(encoded)) {
+			t.Fatalf("Decode(%s) = %x (want: %x)", encoded, decoded, b)
+		}
+	}
+}
+
+const bigAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// bigEncode is the former math/big encoder, kept as a reference.
+func bigEncode(b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	zero := big.NewInt(0)
+	mod := new(big.Int)
+
+	answer := make([]byte, 0, len(b)*136/100)
+	for x.Cmp(zero) > 0 {
+		x.DivMod(x, radix, mod)
+		answer = append(answer, bigAlphabet[mod.Int64()])
+	}
+	for _, i := range b {
+		if i != 0 {
+			break
+		}
+		answer = append(answer, bigAlphabet[0])
+	}
+	for i, j := 0, len(answer)-1; i < j; i, j = i+1, j-1 {
+		answer[i], answer[j] = answer[j], answer[i]
+	}
+
+	return string(answer)
+}
+
+// bigDecode is the former math/big decoder, kept as a reference.
+func bigDecode(s string) []byte {
+	answer := big.NewInt(0)
+	j := big.NewInt(1)
+	radix := big.NewInt(58)
+
+	scratch := new(big.Int)
+	for i := len(s) - 1; i >= 0; i-- {
+		tmp := bytes.IndexByte([]byte(bigAlphabet), s[i])
+		if tmp == -1 {
+			return []byte("")
+		}
+		scratch.SetInt64(int64(tmp))
+		scratch.Mul(j, scratch)
+		answer.Add(answer, scratch)
+		j.Mul(j, radix)
+	}
+
+	tmpval := answer.Bytes()
+	var numZeros int
+	for numZeros = 0; numZeros < len(s); numZeros++ {
+		if s[numZeros] != bigAlphabet[0] {
+			break
+		}
+	}
+
+	val := make([]byte, numZeros+len(tmpval))
+	copy(val[numZeros:], tmpval)
+
+	return val
+}
+
+var benchInput = bytes.Repeat([]byte{0x5a}, 32)
+
+func BenchmarkEncode(b *testing.B) {
+	b.SetBytes(int64(len(benchInput)))
+	for i := 0; i < b.N; i++ {
+		base58.Encode(benchInput)
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	buf := make([]byte, 0, base58.EncodedMaxLen(len(benchInput)))
+	b.SetBytes(int64(len(benchInput)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base58.AppendEncode(buf, benchInput)
+	}
+}
+
+func BenchmarkBigIntEncode(b *testing.B) {
+	b.SetBytes(int64(len(benchInput)))
+	for i := 0; i < b.N; i++ {
+		bigEncode(benchInput)
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	s := base58.Encode(benchInput)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		base58.Decode(s)
+	}
+}
+
+func BenchmarkAppendDecode(b *testing.B) {
+	s := base58.Encode(benchInput)
+	buf := make([]byte, 0, base58.DecodedMaxLen(len(s)))
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		base58.AppendDecode(buf, s)
+	}
+}
+
+func BenchmarkBigIntDecode(b *testing.B) {
+	s := base58.Encode(benchInput)
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		bigDecode(s)
+	}
+}
@@ -2,8 +2,6 @@ package base58
 
 import (
 	"errors"
-
-	"github.com/checksum0/go-cryptoutils/chainhash"
 )
 
 // ErrChecksum ...
@@ -12,47 +10,81 @@ var ErrChecksum = errors.New("checksum error")
 // ErrInvalidFormat ...
 var ErrInvalidFormat = errors.New("invalid format: version and/or checksum bytes missing")
 
-func checksum(input []byte) (chcksum [4]byte) {
-	hash := chainhash.SHA256dToBytes(input)
-	copy(chcksum[:], hash[:4])
+// CheckEncode ...
+func CheckEncode(input []byte, version byte) string {
+	return BitcoinAlphabet.CheckEncode(input, version)
+}
 
-	return
+// CheckDecode ...
+func CheckDecode(input string) (result []byte, version byte, err error) {
+	return BitcoinAlphabet.CheckDecode(input)
 }
 
-// CheckEncode ...
-func CheckEncode(input []byte, version byte) string {
-	b := make([]byte, 0, len(input)+1+4)
+// CheckEncodeVersion ...
+func CheckEncodeVersion(input, version []byte) string {
+	return BitcoinAlphabet.CheckEncodeVersion(input, version)
+}
 
-	b = append(b, version)
-	b = append(b, input[:]...)
+// CheckDecodeVersion ...
+func CheckDecodeVersion(input string, versionLen int) (result, version []byte, err error) {
+	return BitcoinAlphabet.CheckDecodeVersion(input, versionLen)
+}
 
-	chcksum := checksum(b)
+// CheckEncode prepends a version byte and appends the four byte checksum of
+// the alphabet to the input, then encodes it in the alphabet.
+func (a *Alphabet) CheckEncode(input []byte, version byte) string {
+	return a.CheckEncodeVersion(input, []byte{version})
+}
+
+// CheckDecode decodes a string encoded by CheckEncode, verifies its checksum
+// and returns the payload and the version byte.
+func (a *Alphabet) CheckDecode(input string) (result []byte, version byte, err error) {
+	result, v, err := a.CheckDecodeVersion(input, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result, v[0], nil
+}
+
+// CheckEncodeVersion is CheckEncode with a version of any length, such as the
+// four byte versions of BIP32 extended keys.
+func (a *Alphabet) CheckEncodeVersion(input, version []byte) string {
+	b := make([]byte, 0, len(version)+len(input)+4)
+
+	b = append(b, version...)
+	b = append(b, input...)
+
+	chcksum := a.Checksum().Sum(b)
 
 	b = append(b, chcksum[:]...)
 
-	return Encode(b)
+	return a.Encode(b)
 }
 
-// CheckDecode ...
-func CheckDecode(input string) (result []byte, version byte, err error) {
+// CheckDecodeVersion decodes a string encoded by CheckEncodeVersion with a
+// version of versionLen bytes, verifies its checksum and returns the payload
+// and the version.
+func (a *Alphabet) CheckDecodeVersion(input string, versionLen int) (result, version []byte, err error) {
 	var chcksum [4]byte
 
-	decode := Decode(input)
-
-	if len(decode) < 5 {
-		return nil, 0, ErrInvalidFormat
+	decode, err := a.DecodeString(input)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	version = decode[0]
+	if versionLen < 0 || len(decode) < versionLen+4 {
+		return nil, nil, ErrInvalidFormat
+	}
 
 	copy(chcksum[:], decode[len(decode)-4:])
 
-	if checksum(decode[:len(decode)-4]) != chcksum {
-		return nil, 0, ErrChecksum
+	if a.Checksum().Sum(decode[:len(decode)-4]) != chcksum {
+		return nil, nil, ErrChecksum
 	}
 
-	payload := decode[1 : len(decode)-4]
-	result = append(result, payload...)
+	version = decode[:versionLen:versionLen]
+	result = append(result, decode[versionLen:len(decode)-4]...)
 
 	return
 }
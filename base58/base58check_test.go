@@ -1,9 +1,12 @@
 package base58_test
 
 import (
+	"bytes"
+	"encoding/hex"
 	"testing"
 
 	"github.com/checksum0/go-cryptoutils/base58"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
 )
 
 var checkEncodingStringTests = []struct {
@@ -45,6 +48,11 @@ func TestBase58Check(t *testing.T) {
 		t.Error("CheckDecode test failed, expected ErrChecksum")
 	}
 
+	_, _, err = base58.CheckDecode("3MNQE10")
+	if want := (base58.InvalidCharacterError{Char: '0', Offset: 6}); err != want {
+		t.Errorf("CheckDecode test failed, got %v (want: %v)", err, want)
+	}
+
 	testString := ""
 	for length := 0; length < 4; length++ {
 		_, _, err = base58.CheckDecode(testString)
@@ -53,3 +61,52 @@ func TestBase58Check(t *testing.T) {
 		}
 	}
 }
+
+// TestBase58CheckVersion verifies multi-byte versions against the BIP32 test
+// vector 1 master keys, and that single-byte versions match CheckEncode.
+func TestBase58CheckVersion(t *testing.T) {
+	const chainCodeAndKey = "000000000000000000873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508"
+
+	tests := []struct {
+		version []byte
+		in      string
+		out     string
+	}{
+		{
+			bchcfg.MainnetParams.HDPublicKeyID[:],
+			chainCodeAndKey + "0339a36013301597daef41fbe593a02cc513d0b55527ec2df1050e2e8ff49c85c2",
+			"xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			bchcfg.MainnetParams.HDPrivateKeyID[:],
+			chainCodeAndKey + "00e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			"xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+		},
+		{[]byte{20}, hex.EncodeToString([]byte("abc")), "4QiVtDjUdeq"},
+		{[]byte{0x1c, 0xb8}, "00", "2685po2AjN"},
+		{nil, "00", "1Wh4bh"},
+	}
+
+	for x, test := range tests {
+		in, _ := hex.DecodeString(test.in)
+		if result := base58.CheckEncodeVersion(in, test.version); result != test.out {
+			t.Errorf("CheckEncodeVersion(%d) = %s (want: %s)", x, result, test.out)
+		}
+
+		result, version, err := base58.CheckDecodeVersion(test.out, len(test.version))
+		if err != nil {
+			t.Errorf("CheckDecodeVersion(%d) = err %v", x, err)
+		} else if !bytes.Equal(version, test.version) {
+			t.Errorf("CheckDecodeVersion(%d) = version %x (want: %x)", x, version, test.version)
+		} else if !bytes.Equal(result, in) {
+			t.Errorf("CheckDecodeVersion(%d) = %x (want: %s)", x, result, test.in)
+		}
+	}
+
+	for _, versionLen := range []int{-1, 79} {
+		_, _, err := base58.CheckDecodeVersion(tests[0].out, versionLen)
+		if err != base58.ErrInvalidFormat {
+			t.Errorf("CheckDecodeVersion(%d) = err %v (want: %v)", versionLen, err, base58.ErrInvalidFormat)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package base58
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// blake256 implements the BLAKE-256 hash function (14 rounds), as used by
+// Decred, for the BLAKE256d checksum.
+
+var blake256IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var blake256U = [16]uint32{
+	0x243f6a88, 0x85a308d3, 0x13198a2e, 0x03707344,
+	0xa4093822, 0x299f31d0, 0x082efa98, 0xec4e6c89,
+	0x452821e6, 0x38d01377, 0xbe5466cf, 0x34e90c6c,
+	0xc0ac29b7, 0xc97c50dd, 0x3f84d5b5, 0xb5470917,
+}
+
+var blake256Sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// blake256 returns the BLAKE-256 hash of data, with a zero salt.
+func blake256(data []byte) (sum [32]byte) {
+	h := blake256IV
+	length := uint64(len(data)) * 8
+
+	var t uint64
+	for len(data) >= 64 {
+		t += 512
+		blake256Block(&h, data[:64], t)
+		data = data[64:]
+	}
+
+	// The final message bytes are padded with a one bit, zeros, a one bit and
+	// the message length. The counter of a block holding no message bits is
+	// zero.
+	var tail [128]byte
+	n := copy(tail[:], data)
+	tail[n] = 0x80
+
+	size := 64
+	if n >= 56 {
+		size = 128
+	}
+	tail[size-9] |= 0x01
+	binary.BigEndian.PutUint64(tail[size-8:], length)
+
+	if size == 128 {
+		blake256Block(&h, tail[:64], length)
+		blake256Block(&h, tail[64:], 0)
+	} else if n == 0 {
+		blake256Block(&h, tail[:64], 0)
+	} else {
+		blake256Block(&h, tail[:64], length)
+	}
+
+	for i, v := range h {
+		binary.BigEndian.PutUint32(sum[i*4:], v)
+	}
+
+	return
+}
+
+// blake256Block compresses one 64-byte block into h, where t is the number of
+// message bits hashed up to the end of the block.
+func blake256Block(h *[8]uint32, block []byte, t uint64) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.BigEndian.Uint32(block[i*4:])
+	}
+
+	var v [16]uint32
+	copy(v[:8], h[:])
+	copy(v[8:], blake256U[:8])
+	v[12] ^= uint32(t)
+	v[13] ^= uint32(t)
+	v[14] ^= uint32(t >> 32)
+	v[15] ^= uint32(t >> 32)
+
+	g := func(s *[16]byte, i, a, b, c, d int) {
+		x, y := s[2*i], s[2*i+1]
+		v[a] += v[b] + (m[x] ^ blake256U[y])
+		v[d] = bits.RotateLeft32(v[d]^v[a], -16)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -12)
+		v[a] += v[b] + (m[y] ^ blake256U[x])
+		v[d] = bits.RotateLeft32(v[d]^v[a], -8)
+		v[c] += v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -7)
+	}
+
+	for r := 0; r < 14; r++ {
+		s := &blake256Sigma[r%10]
+		g(s, 0, 0, 4, 8, 12)
+		g(s, 1, 1, 5, 9, 13)
+		g(s, 2, 2, 6, 10, 14)
+		g(s, 3, 3, 7, 11, 15)
+		g(s, 4, 0, 5, 10, 15)
+		g(s, 5, 1, 6, 11, 12)
+		g(s, 6, 2, 7, 8, 13)
+		g(s, 7, 3, 4, 9, 14)
+	}
+
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
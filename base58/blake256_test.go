@@ -0,0 +1,26 @@
+package base58
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestBlake256 verifies BLAKE-256 against the test vectors of its
+// specification.
+func TestBlake256(t *testing.T) {
+	tests := []struct {
+		in  []byte
+		out string
+	}{
+		{nil, "716f6e863f744b9ac22c97ec7b76ea5f5908bc5b2f67c61510bfc4751384ea7a"},
+		{[]byte{0}, "0ce8d4ef4dd7cd8d62dfded9d4edb0a774ae6a41929a74da23109e8f11139c87"},
+		{make([]byte, 72), "d419bad32d504fb7d44d460c42c5593fe544fa4c135dec31e21bd9abdcc22d41"},
+	}
+
+	for x, test := range tests {
+		if sum := blake256(test.in); hex.EncodeToString(sum[:]) != test.out {
+			t.Errorf("blake256(%d) = %x (want: %s)", x, sum, test.out)
+		}
+	}
+
+}
@@ -0,0 +1,89 @@
+package base58
+
+import (
+	"crypto/sha256"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+// Checksum computes the four byte checksum the check encodings append to the
+// version and payload.
+type Checksum interface {
+	// Name returns a short name for the checksum, such as "sha256d".
+	Name() string
+
+	// Sum returns the checksum of data.
+	Sum(data []byte) [4]byte
+}
+
+// Checksums of the check encodings.
+var (
+	// SHA256d is the first four bytes of the double SHA-256 hash, as used by
+	// Bitcoin. It is the default checksum of every alphabet.
+	SHA256d Checksum = sha256dChecksum{}
+
+	// SHA256 is the first four bytes of a single SHA-256 hash.
+	SHA256 Checksum = sha256Checksum{}
+
+	// BLAKE256d is the first four bytes of the double BLAKE-256 hash, as used
+	// by Decred.
+	BLAKE256d Checksum = blake256dChecksum{}
+)
+
+type sha256dChecksum struct{}
+
+func (sha256dChecksum) Name() string {
+	return "sha256d"
+}
+
+func (sha256dChecksum) Sum(data []byte) (sum [4]byte) {
+	hash := chainhash.SHA256dToBytes(data)
+	copy(sum[:], hash[:4])
+
+	return
+}
+
+type sha256Checksum struct{}
+
+func (sha256Checksum) Name() string {
+	return "sha256"
+}
+
+func (sha256Checksum) Sum(data []byte) (sum [4]byte) {
+	hash := sha256.Sum256(data)
+	copy(sum[:], hash[:4])
+
+	return
+}
+
+type blake256dChecksum struct{}
+
+func (blake256dChecksum) Name() string {
+	return "blake256d"
+}
+
+func (blake256dChecksum) Sum(data []byte) (sum [4]byte) {
+	hash := blake256(data)
+	hash = blake256(hash[:])
+	copy(sum[:], hash[:4])
+
+	return
+}
+
+// WithChecksum returns a copy of the alphabet whose check encodings use the
+// given checksum.
+func (a *Alphabet) WithChecksum(checksum Checksum) *Alphabet {
+	b := *a
+	b.checksum = checksum
+
+	return &b
+}
+
+// Checksum returns the checksum of the check encodings of the alphabet.
+func (a *Alphabet) Checksum() Checksum {
+	if a.checksum == nil {
+		return SHA256d
+	}
+
+	return a.checksum
+}
@@ -0,0 +1,55 @@
+package base58_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/base58"
+)
+
+// TestChecksum verifies check encodings with each checksum, using Decred
+// addresses for BLAKE256d, and that alphabets default to SHA256d.
+func TestChecksum(t *testing.T) {
+	tests := []struct {
+		checksum base58.Checksum
+		version  string
+		in       string
+		out      string
+	}{
+		{base58.SHA256d, "14", "616263", "4QiVtDjUdeq"},
+		{base58.SHA256, "14", "616263", "4QiVtEDStoL"},
+		{base58.BLAKE256d, "073f", "2789d58cfa0957d206f025c2af056fc8a77cebb0", "DsUZxxoHJSty8DCfwfartwTYbuhmVct7tJu"},
+		{base58.BLAKE256d, "073f", "64e20eb6075561d30c23a517c5b73badbc120f05", "DsaAKsMvZ6HrqhmbhLjV9qVbPkkzF5daowT"},
+		{base58.BLAKE256d, "0f21", "e0c3ca922d236d1324ef4fb3cc468cc156cf0882", "TsmWaPM77WSyA3aiQ2Q1KnwGDVWvEkhipBc"},
+	}
+
+	for x, test := range tests {
+		a := base58.BitcoinAlphabet.WithChecksum(test.checksum)
+		version, _ := hex.DecodeString(test.version)
+		in, _ := hex.DecodeString(test.in)
+
+		if result := a.CheckEncodeVersion(in, version); result != test.out {
+			t.Errorf("%s CheckEncodeVersion(%d) = %s (want: %s)", test.checksum.Name(), x, result, test.out)
+		}
+
+		result, _, err := a.CheckDecodeVersion(test.out, len(version))
+		if err != nil || !bytes.Equal(result, in) {
+			t.Errorf("%s CheckDecodeVersion(%d) = %x, %v (want: %s)", test.checksum.Name(), x, result, err, test.in)
+		}
+
+		if test.checksum != base58.SHA256d {
+			if _, _, err := base58.CheckDecodeVersion(test.out, len(version)); err != base58.ErrChecksum {
+				t.Errorf("sha256d CheckDecodeVersion(%d) = err %v (want: %v)", x, err, base58.ErrChecksum)
+			}
+		}
+	}
+
+	if c := base58.BitcoinAlphabet.Checksum(); c != base58.SHA256d {
+		t.Errorf("BitcoinAlphabet.Checksum() = %s (want: sha256d)", c.Name())
+	}
+	base58.BitcoinAlphabet.WithChecksum(base58.SHA256)
+	if base58.BitcoinAlphabet.Checksum() != base58.SHA256d {
+		t.Error("WithChecksum modified its receiver")
+	}
+}
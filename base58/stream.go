@@ -0,0 +1,277 @@
+package base58
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrInputTooLong is returned by encoders and decoders when their input
+// exceeds the maximum length they were created with.
+var ErrInputTooLong = errors.New("base58 input exceeds the maximum length")
+
+var errEncoderClosed = errors.New("base58 encoder is closed")
+
+// Base58 is not a block encoding: every output digit depends on the whole
+// input. Encoder and Decoder therefore buffer their input and convert it at
+// once, in O(n) memory and O(n^2) time. They convert 32-bit words into base
+// 58^5 limbs rather than bytes into digits, which is about twenty times faster
+// than AppendEncode and AppendDecode on large inputs but allocates. Encoding
+// or decoding 100 KiB still takes around a second, so inputs from untrusted
+// sources must be capped with maxLen.
+
+// limbBase is the largest power of 58 that fits in 32 bits, and limbDigits its
+// number of digits.
+const (
+	limbBase   = 58 * 58 * 58 * 58 * 58
+	limbDigits = 5
+)
+
+// Encoder is an io.WriteCloser that base58 encodes the bytes written to it and
+// writes the encoding to the underlying writer on Close.
+type Encoder struct {
+	alphabet *Alphabet
+	w        io.Writer
+	maxLen   int
+	buf      []byte
+	err      error
+}
+
+// NewEncoder returns an encoder using the Bitcoin alphabet. See
+// Alphabet.NewEncoder.
+func NewEncoder(w io.Writer, maxLen int) *Encoder {
+	return BitcoinAlphabet.NewEncoder(w, maxLen)
+}
+
+// NewEncoder returns an encoder writing the encoding of its input to w. Writes
+// beyond maxLen bytes in total fail with ErrInputTooLong, unless maxLen is not
+// positive.
+func (a *Alphabet) NewEncoder(w io.Writer, maxLen int) *Encoder {
+	return &Encoder{
+		alphabet: a,
+		w:        w,
+		maxLen:   maxLen,
+	}
+}
+
+// Write buffers p until Close.
+func (e *Encoder) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	if e.maxLen > 0 && len(p) > e.maxLen-len(e.buf) {
+		e.err = ErrInputTooLong
+		return 0, e.err
+	}
+
+	e.buf = append(e.buf, p...)
+
+	return len(p), nil
+}
+
+// Close encodes the buffered input and writes it to the underlying writer. It
+// does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+
+	_, err := e.w.Write(e.alphabet.encodeLimbs(e.buf))
+	e.buf = nil
+	e.err = errEncoderClosed
+
+	return err
+}
+
+// Decoder is an io.Reader that decodes the base58 string read from the
+// underlying reader. The whole string is read and decoded on the first Read.
+type Decoder struct {
+	alphabet *Alphabet
+	r        io.Reader
+	maxLen   int
+	out      []byte
+	err      error
+	decoded  bool
+}
+
+// NewDecoder returns a decoder using the Bitcoin alphabet. See
+// Alphabet.NewDecoder.
+func NewDecoder(r io.Reader, maxLen int) *Decoder {
+	return BitcoinAlphabet.NewDecoder(r, maxLen)
+}
+
+// NewDecoder returns a decoder of the base58 string read from r. Reads fail
+// with ErrInputTooLong if r holds more than maxLen characters, unless maxLen
+// is not positive, and with an InvalidCharacterError on characters outside of
+// the alphabet.
+func (a *Alphabet) NewDecoder(r io.Reader, maxLen int) *Decoder {
+	return &Decoder{
+		alphabet: a,
+		r:        r,
+		maxLen:   maxLen,
+	}
+}
+
+// Read reads decoded bytes into p.
+func (d *Decoder) Read(p []byte) (int, error) {
+	if !d.decoded {
+		d.decoded = true
+		d.out, d.err = d.decode()
+	}
+
+	if len(d.out) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		return 0, io.EOF
+	}
+
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+
+	return n, nil
+}
+
+func (d *Decoder) decode() ([]byte, error) {
+	r := d.r
+	if d.maxLen > 0 {
+		r = io.LimitReader(r, int64(d.maxLen)+1)
+	}
+
+	encoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if d.maxLen > 0 && len(encoded) > d.maxLen {
+		return nil, ErrInputTooLong
+	}
+
+	return d.alphabet.decodeLimbs(encoded)
+}
+
+// encodeLimbs returns the encoding of src, computed over little endian base
+// 58^5 limbs.
+func (a *Alphabet) encodeLimbs(src []byte) []byte {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+	src = src[zeros:]
+
+	limbs := make([]uint32, 0, EncodedMaxLen(len(src))/limbDigits+1)
+
+	// The input is consumed as big endian 32-bit words, the first of them
+	// holding the len(src)%4 leading bytes if any.
+	for len(src) > 0 {
+		n := len(src) % 4
+		if n == 0 {
+			n = 4
+		}
+
+		var word uint64
+		for _, b := range src[:n] {
+			word = word<<8 | uint64(b)
+		}
+		src = src[n:]
+
+		carry := word
+		for i, limb := range limbs {
+			x := uint64(limb)<<(8*uint(n)) | carry
+			limbs[i] = uint32(x % limbBase)
+			carry = x / limbBase
+		}
+		for carry != 0 {
+			limbs = append(limbs, uint32(carry%limbBase))
+			carry /= limbBase
+		}
+	}
+
+	out := make([]byte, zeros, zeros+len(limbs)*limbDigits)
+	for i := range out {
+		out[i] = a.encode[0]
+	}
+
+	var digits [limbDigits]byte
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limb := limbs[i]
+		for j := limbDigits - 1; j >= 0; j-- {
+			digits[j] = a.encode[limb%58]
+			limb /= 58
+		}
+
+		if i == len(limbs)-1 {
+			// The most significant limb is written without leading zeros.
+			k := 0
+			for k < limbDigits-1 && digits[k] == a.encode[0] {
+				k++
+			}
+			out = append(out, digits[k:]...)
+		} else {
+			out = append(out, digits[:]...)
+		}
+	}
+
+	return out
+}
+
+// decodeLimbs returns the decoding of src, computed over little endian 32-bit
+// limbs.
+func (a *Alphabet) decodeLimbs(src []byte) ([]byte, error) {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == a.encode[0] {
+		zeros++
+	}
+
+	for i := zeros; i < len(src); i++ {
+		if a.decode[src[i]] == 255 {
+			return nil, InvalidCharacterError{Char: src[i], Offset: i}
+		}
+	}
+
+	limbs := make([]uint32, 0, DecodedMaxLen(len(src)-zeros)/4+1)
+
+	// The input is consumed five characters at a time, the first group
+	// holding the leading len%5 characters if any.
+	rest := src[zeros:]
+	for len(rest) > 0 {
+		n := len(rest) % limbDigits
+		if n == 0 {
+			n = limbDigits
+		}
+
+		var group, mul uint64 = 0, 1
+		for _, c := range rest[:n] {
+			group = group*58 + uint64(a.decode[c])
+			mul *= 58
+		}
+		rest = rest[n:]
+
+		carry := group
+		for i, limb := range limbs {
+			x := uint64(limb)*mul + carry
+			limbs[i] = uint32(x)
+			carry = x >> 32
+		}
+		for carry != 0 {
+			limbs = append(limbs, uint32(carry))
+			carry >>= 32
+		}
+	}
+
+	out := make([]byte, zeros, zeros+len(limbs)*4)
+	for i := len(limbs) - 1; i >= 0; i-- {
+		var word [4]byte
+		binary.BigEndian.PutUint32(word[:], limbs[i])
+
+		k := 0
+		if i == len(limbs)-1 {
+			// The most significant limb is written without leading zeros.
+			for k < 3 && word[k] == 0 {
+				k++
+			}
+		}
+		out = append(out, word[k:]...)
+	}
+
+	return out, nil
+}
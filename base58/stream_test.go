@@ -0,0 +1,116 @@
+package base58_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/checksum0/go-cryptoutils/base58"
+)
+
+// TestStream verifies that Encoder and Decoder match Encode and Decode, on the
+// test vectors and on random inputs with leading zeros.
+func TestStream(t *testing.T) {
+	var inputs [][]byte
+	for _, test := range hexTests {
+		b, _ := hex.DecodeString(test.in)
+		inputs = append(inputs, b)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 3, 4, 5, 31, 32, 33, 250, 1000, 4096} {
+		b := make([]byte, n)
+		rng.Read(b)
+		for j := 0; j < len(b) && rng.Intn(3) == 0; j++ {
+			b[j] = 0
+		}
+		inputs = append(inputs, b)
+	}
+
+	for x, in := range inputs {
+		var buf bytes.Buffer
+		e := base58.NewEncoder(&buf, 0)
+		for _, b := range in {
+			if _, err := e.Write([]byte{b}); err != nil {
+				t.Fatalf("Encoder.Write(%d) = err %v", x, err)
+			}
+		}
+		if err := e.Close(); err != nil {
+			t.Fatalf("Encoder.Close(%d) = err %v", x, err)
+		}
+
+		want := base58.Encode(in)
+		if buf.String() != want {
+			t.Errorf("Encoder(%d) = %s (want: %s)", x, buf.String(), want)
+			continue
+		}
+
+		d := base58.NewDecoder(iotest.OneByteReader(strings.NewReader(want)), 0)
+		decoded, err := io.ReadAll(iotest.OneByteReader(d))
+		if err != nil || !bytes.Equal(decoded, in) {
+			t.Errorf("Decoder(%d) = %x, %v (want: %x)", x, decoded, err, in)
+		}
+	}
+}
+
+// TestStreamErrors verifies the length limits and invalid characters of
+// Encoder and Decoder.
+func TestStreamErrors(t *testing.T) {
+	e := base58.NewEncoder(io.Discard, 4)
+	if _, err := e.Write([]byte{1, 2, 3}); err != nil {
+		t.Errorf("Encoder.Write(3 bytes) = err %v", err)
+	}
+	if _, err := e.Write([]byte{4, 5}); err != base58.ErrInputTooLong {
+		t.Errorf("Encoder.Write(5 bytes) = err %v (want: %v)", err, base58.ErrInputTooLong)
+	}
+	if err := e.Close(); err != base58.ErrInputTooLong {
+		t.Errorf("Encoder.Close() = err %v (want: %v)", err, base58.ErrInputTooLong)
+	}
+
+	e = base58.NewEncoder(io.Discard, 0)
+	e.Close()
+	if _, err := e.Write([]byte{1}); err == nil {
+		t.Error("Encoder.Write after Close succeeded")
+	}
+
+	tests := []struct {
+		in     string
+		maxLen int
+		err    error
+	}{
+		{"2cFupjhnEsSn59qHXstmK2ffpLv2", 28, nil},
+		{"2cFupjhnEsSn59qHXstmK2ffpLv2", 27, base58.ErrInputTooLong},
+		{"2cFupjhnEsSn59qHXstmK2ffpLv2", 0, nil},
+		{"1112cFupjhn0", 0, base58.InvalidCharacterError{Char: '0', Offset: 11}},
+		{"111l", 0, base58.InvalidCharacterError{Char: 'l', Offset: 3}},
+	}
+
+	for x, test := range tests {
+		_, err := io.ReadAll(base58.NewDecoder(strings.NewReader(test.in), test.maxLen))
+		if err != test.err {
+			t.Errorf("Decoder(%d) = err %v (want: %v)", x, err, test.err)
+		}
+	}
+}
+
+func BenchmarkEncoder(b *testing.B) {
+	in := bytes.Repeat([]byte{0x5a}, 4096)
+	b.SetBytes(int64(len(in)))
+	for i := 0; i < b.N; i++ {
+		e := base58.NewEncoder(io.Discard, 0)
+		e.Write(in)
+		e.Close()
+	}
+}
+
+func BenchmarkDecoder(b *testing.B) {
+	s := base58.Encode(bytes.Repeat([]byte{0x5a}, 4096))
+	b.SetBytes(int64(len(s)))
+	for i := 0; i < b.N; i++ {
+		io.Copy(io.Discard, base58.NewDecoder(strings.NewReader(s), 0))
+	}
+}
@@ -0,0 +1,279 @@
+package bech32
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxLength is the maximum length of a bech32 string under BIP173. Longer
+// strings, such as Lightning invoices, can be decoded with DecodeLimit.
+const MaxLength = 90
+
+// Encoding is the checksum variant of a bech32 string.
+type Encoding int
+
+// Checksum variants.
+const (
+	// Bech32 is the original checksum of BIP173.
+	Bech32 Encoding = iota + 1
+
+	// Bech32m is the checksum of BIP350.
+	Bech32m
+)
+
+var (
+	// ErrInvalidCharacter ...
+	ErrInvalidCharacter = errors.New("invalid bech32 character")
+
+	// ErrInvalidLength ...
+	ErrInvalidLength = errors.New("bech32 string exceeds the maximum length")
+
+	// ErrMixedCase ...
+	ErrMixedCase = errors.New("bech32 string mixes upper and lower case")
+
+	// ErrMissingSeparator ...
+	ErrMissingSeparator = errors.New("bech32 separator '1' is missing")
+
+	// ErrInvalidHRP ...
+	ErrInvalidHRP = errors.New("bech32 human-readable part must be 1 to 83 characters")
+
+	// ErrShortData ...
+	ErrShortData = errors.New("bech32 data part is shorter than its checksum")
+
+	// ErrInvalidChecksum ...
+	ErrInvalidChecksum = errors.New("invalid bech32 checksum")
+
+	// ErrInvalidDataRange ...
+	ErrInvalidDataRange = errors.New("bech32 data value out of range")
+
+	// ErrInvalidPadding ...
+	ErrInvalidPadding = errors.New("invalid bech32 padding")
+)
+
+// InvalidCharacterError describes an invalid character and its byte offset in
+// the bech32 string. It matches ErrInvalidCharacter with errors.Is.
+type InvalidCharacterError struct {
+	Char   byte
+	Offset int
+}
+
+func (e InvalidCharacterError) Error() string {
+	return fmt.Sprintf("%v %q at offset %d", ErrInvalidCharacter, e.Char, e.Offset)
+}
+
+// Is reports whether target is ErrInvalidCharacter.
+func (e InvalidCharacterError) Is(target error) bool {
+	return target == ErrInvalidCharacter
+}
+
+// String returns the name of the encoding.
+func (e Encoding) String() string {
+	switch e {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	default:
+		return fmt.Sprintf("Encoding(%d)", int(e))
+	}
+}
+
+// Charset is the alphabet of the data part, mapping 5-bit values to
+// characters. Cash addresses use it too.
+const Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var charsetRev = func() (rev [256]int8) {
+	for i := range rev {
+		rev[i] = -1
+	}
+	for i := 0; i < len(Charset); i++ {
+		rev[Charset[i]] = int8(i)
+	}
+
+	return
+}()
+
+// CharsetIndex returns the 5-bit value of a lowercase character of Charset, or
+// -1 if c is not one.
+func CharsetIndex(c byte) int {
+	return int(charsetRev[c])
+}
+
+const bech32mConst = 0x2bc830a3
+
+func (e Encoding) constant() uint32 {
+	if e == Bech32m {
+		return bech32mConst
+	}
+
+	return 1
+}
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// polymodStep feeds one 5-bit value to the checksum state chk.
+func polymodStep(chk uint32, v byte) uint32 {
+	top := chk >> 25
+	chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+	for i, g := range generator {
+		if (top>>uint(i))&1 == 1 {
+			chk ^= g
+		}
+	}
+
+	return chk
+}
+
+func polymod(values []byte, chk uint32) uint32 {
+	for _, v := range values {
+		chk = polymodStep(chk, v)
+	}
+
+	return chk
+}
+
+// hrpChecksum returns the checksum state after the expanded human-readable
+// part.
+func hrpChecksum(hrp string) uint32 {
+	chk := uint32(1)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, hrp[i]>>5)
+	}
+	chk = polymodStep(chk, 0)
+	for i := 0; i < len(hrp); i++ {
+		chk = polymodStep(chk, hrp[i]&31)
+	}
+
+	return chk
+}
+
+// Encode returns the bech32 string of the human-readable part and the 5-bit
+// data values, with the checksum of the given encoding. The human-readable part
+// is lowercased. The result is not limited to MaxLength.
+func Encode(hrp string, data []byte, enc Encoding) (string, error) {
+	if len(hrp) < 1 || len(hrp) > 83 {
+		return "", ErrInvalidHRP
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", InvalidCharacterError{Char: hrp[i], Offset: i}
+		}
+	}
+	for _, v := range data {
+		if v >= 32 {
+			return "", ErrInvalidDataRange
+		}
+	}
+
+	hrp = strings.ToLower(hrp)
+	chk := polymod(data, hrpChecksum(hrp))
+	chk = polymod(make([]byte, 6), chk) ^ enc.constant()
+
+	var b strings.Builder
+	b.Grow(len(hrp) + 1 + len(data) + 6)
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range data {
+		b.WriteByte(Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		b.WriteByte(Charset[(chk>>uint(5*(5-i)))&31])
+	}
+
+	return b.String(), nil
+}
+
+// Decode decodes a bech32 string of at most MaxLength characters, returning
+// its lowercased human-readable part, its 5-bit data values without the
+// checksum, and the encoding of the checksum.
+func Decode(s string) (hrp string, data []byte, enc Encoding, err error) {
+	return DecodeLimit(s, MaxLength)
+}
+
+// DecodeLimit is Decode with a maximum length other than MaxLength.
+func DecodeLimit(s string, limit int) (hrp string, data []byte, enc Encoding, err error) {
+	if len(s) > limit {
+		return "", nil, 0, ErrInvalidLength
+	}
+
+	var lower, upper bool
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 33 || c > 126 {
+			return "", nil, 0, InvalidCharacterError{Char: c, Offset: i}
+		}
+		lower = lower || c >= 'a' && c <= 'z'
+		upper = upper || c >= 'A' && c <= 'Z'
+	}
+	if lower && upper {
+		return "", nil, 0, ErrMixedCase
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	switch {
+	case sep < 0:
+		return "", nil, 0, ErrMissingSeparator
+	case sep < 1 || sep > 83:
+		return "", nil, 0, ErrInvalidHRP
+	case len(s)-sep-1 < 6:
+		return "", nil, 0, ErrShortData
+	}
+
+	hrp = s[:sep]
+	values := make([]byte, len(s)-sep-1)
+	for i := range values {
+		c := s[sep+1+i]
+		v := charsetRev[c]
+		if v < 0 {
+			return "", nil, 0, InvalidCharacterError{Char: c, Offset: sep + 1 + i}
+		}
+		values[i] = byte(v)
+	}
+
+	switch polymod(values, hrpChecksum(hrp)) {
+	case Bech32.constant():
+		enc = Bech32
+	case Bech32m.constant():
+		enc = Bech32m
+	default:
+		return "", nil, 0, ErrInvalidChecksum
+	}
+
+	return hrp, values[:len(values)-6], enc, nil
+}
+
+// ConvertBits regroups data from fromBits-bit to toBits-bit values, both at
+// most 8. With pad, the last value is padded with zero bits; without it, the
+// input must not hold more than fromBits-1 bits of padding, all zero.
+func ConvertBits(data []byte, fromBits, toBits uint8, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint8
+	maxv := uint32(1)<<toBits - 1
+	maxAcc := uint32(1)<<(fromBits+toBits-1) - 1
+
+	out := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if v>>fromBits != 0 {
+			return nil, ErrInvalidDataRange
+		}
+
+		acc = (acc<<fromBits | uint32(v)) & maxAcc
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, ErrInvalidPadding
+	}
+
+	return out, nil
+}
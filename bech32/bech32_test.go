@@ -0,0 +1,155 @@
+package bech32_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/bech32"
+)
+
+// TestDecodeValid verifies the valid test vectors of BIP173 and BIP350, and
+// that re-encoding them gives back their lowercase form.
+func TestDecodeValid(t *testing.T) {
+	tests := []struct {
+		in  string
+		enc bech32.Encoding
+	}{
+		{"A12UEL5L", bech32.Bech32},
+		{"a12uel5l", bech32.Bech32},
+		{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", bech32.Bech32},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", bech32.Bech32},
+		{"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j", bech32.Bech32},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", bech32.Bech32},
+		{"?1ezyfcl", bech32.Bech32},
+		{"A1LQFN3A", bech32.Bech32m},
+		{"a1lqfn3a", bech32.Bech32m},
+		{"an83characterlonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11sg7hg6", bech32.Bech32m},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", bech32.Bech32m},
+		{"11llllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllllludsr8", bech32.Bech32m},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", bech32.Bech32m},
+		{"?1v759aa", bech32.Bech32m},
+	}
+
+	for _, test := range tests {
+		hrp, data, enc, err := bech32.Decode(test.in)
+		if err != nil {
+			t.Errorf("Decode(%s) = err %v", test.in, err)
+			continue
+		}
+		if enc != test.enc {
+			t.Errorf("Decode(%s) = encoding %v (want: %v)", test.in, enc, test.enc)
+		}
+
+		encoded, err := bech32.Encode(hrp, data, enc)
+		if want := strings.ToLower(test.in); err != nil || encoded != want {
+			t.Errorf("Encode(Decode(%s)) = %s, %v (want: %s)", test.in, encoded, err, want)
+		}
+	}
+}
+
+// TestDecodeInvalid verifies the invalid test vectors of BIP173 and BIP350,
+// along with the error and the offset of invalid characters.
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		in  string
+		err error
+	}{
+		{"\x201nwldj5", bech32.InvalidCharacterError{Char: 0x20, Offset: 0}},
+		{"\x7f1axkwrx", bech32.InvalidCharacterError{Char: 0x7f, Offset: 0}},
+		{"\x801eym55h", bech32.InvalidCharacterError{Char: 0x80, Offset: 0}},
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", bech32.ErrInvalidLength},
+		{"pzry9x0s0muk", bech32.ErrMissingSeparator},
+		{"1pzry9x0s0muk", bech32.ErrInvalidHRP},
+		{"x1b4n0q5v", bech32.InvalidCharacterError{Char: 'b', Offset: 2}},
+		{"li1dgmt3", bech32.ErrShortData},
+		{"de1lg7wt\xff", bech32.InvalidCharacterError{Char: 0xff, Offset: 8}},
+		{"A1G7SGD8", bech32.ErrInvalidChecksum},
+		{"10a06t8", bech32.ErrInvalidHRP},
+		{"1qzzfhee", bech32.ErrInvalidHRP},
+		{"\x201xj0phk", bech32.InvalidCharacterError{Char: 0x20, Offset: 0}},
+		{"\x7f1g6xzxy", bech32.InvalidCharacterError{Char: 0x7f, Offset: 0}},
+		{"\x801vctc34", bech32.InvalidCharacterError{Char: 0x80, Offset: 0}},
+		{"an84characterslonghumanreadablepartthatcontainsthetheexcludedcharactersbioandnumber11d6pts4", bech32.ErrInvalidLength},
+		{"qyrz8wqd2c9m", bech32.ErrMissingSeparator},
+		{"1qyrz8wqd2c9m", bech32.ErrInvalidHRP},
+		{"y1b0jsk6g", bech32.InvalidCharacterError{Char: 'b', Offset: 2}},
+		{"lt1igcx5c0", bech32.InvalidCharacterError{Char: 'i', Offset: 3}},
+		{"in1muywd", bech32.ErrShortData},
+		{"mm1crxm3i", bech32.InvalidCharacterError{Char: 'i', Offset: 8}},
+		{"au1s5cgom", bech32.InvalidCharacterError{Char: 'o', Offset: 7}},
+		{"M1VUXWEZ", bech32.ErrInvalidChecksum},
+		{"16plkw9", bech32.ErrInvalidHRP},
+		{"1p2gdwpf", bech32.ErrInvalidHRP},
+		{"a12UEL5L", bech32.ErrMixedCase},
+	}
+
+	for _, test := range tests {
+		if _, _, _, err := bech32.Decode(test.in); err != test.err {
+			t.Errorf("Decode(%q) = err %v (want: %v)", test.in, err, test.err)
+		}
+	}
+}
+
+// TestDecodeLimit verifies that DecodeLimit accepts strings longer than
+// MaxLength, as used by Lightning invoices.
+func TestDecodeLimit(t *testing.T) {
+	data := bytes.Repeat([]byte{7}, 200)
+	s, err := bech32.Encode("lnbc", data, bech32.Bech32)
+	if err != nil {
+		t.Fatalf("Encode = err %v", err)
+	}
+
+	if _, _, _, err := bech32.Decode(s); err != bech32.ErrInvalidLength {
+		t.Errorf("Decode(%d characters) = err %v (want: %v)", len(s), err, bech32.ErrInvalidLength)
+	}
+
+	hrp, decoded, _, err := bech32.DecodeLimit(s, 1023)
+	if err != nil || hrp != "lnbc" || !bytes.Equal(decoded, data) {
+		t.Errorf("DecodeLimit(%d characters) = %s, %v, %v", len(s), hrp, decoded, err)
+	}
+}
+
+// TestConvertBits verifies regrouping between 8-bit and 5-bit values and the
+// padding rules.
+func TestConvertBits(t *testing.T) {
+	tests := []struct {
+		in       []byte
+		from, to uint8
+		pad      bool
+		out      []byte
+		err      error
+	}{
+		{[]byte{0xff}, 8, 5, true, []byte{31, 28}, nil},
+		{[]byte{0xff}, 8, 5, false, nil, bech32.ErrInvalidPadding},
+		{[]byte{31, 28}, 5, 8, false, []byte{0xff}, nil},
+		{[]byte{31, 29}, 5, 8, false, nil, bech32.ErrInvalidPadding},
+		{[]byte{31, 28, 0}, 5, 8, false, nil, bech32.ErrInvalidPadding},
+		{[]byte{0x00, 0x01, 0x02, 0x03, 0x04}, 8, 5, false, []byte{0, 0, 0, 16, 4, 0, 24, 4}, nil},
+		{[]byte{32}, 5, 8, true, nil, bech32.ErrInvalidDataRange},
+		{nil, 8, 5, true, []byte{}, nil},
+	}
+
+	for x, test := range tests {
+		out, err := bech32.ConvertBits(test.in, test.from, test.to, test.pad)
+		if err != test.err || !bytes.Equal(out, test.out) {
+			t.Errorf("ConvertBits(%d) = %v, %v (want: %v, %v)", x, out, err, test.out, test.err)
+		}
+	}
+}
+
+// TestCharsetIndex verifies that CharsetIndex inverts Charset and rejects the
+// characters outside of it, uppercase included.
+func TestCharsetIndex(t *testing.T) {
+	for i := 0; i < len(bech32.Charset); i++ {
+		if v := bech32.CharsetIndex(bech32.Charset[i]); v != i {
+			t.Errorf("CharsetIndex(%q) = %d (want: %d)", bech32.Charset[i], v, i)
+		}
+	}
+
+	for _, c := range []byte{'1', 'b', 'i', 'o', 'Q', 0, 0xff} {
+		if v := bech32.CharsetIndex(c); v != -1 {
+			t.Errorf("CharsetIndex(%q) = %d (want: -1)", c, v)
+		}
+	}
+}
@@ -0,0 +1 @@
+package bech32
@@ -0,0 +1,89 @@
+package bech32
+
+import (
+	"errors"
+)
+
+var (
+	// ErrHRPMismatch ...
+	ErrHRPMismatch = errors.New("segwit address has an unexpected human-readable part")
+
+	// ErrInvalidWitnessVersion ...
+	ErrInvalidWitnessVersion = errors.New("invalid witness version")
+
+	// ErrInvalidProgramLength ...
+	ErrInvalidProgramLength = errors.New("invalid witness program length")
+
+	// ErrInvalidEncoding ...
+	ErrInvalidEncoding = errors.New("segwit address checksum does not match its witness version")
+)
+
+// segwitEncoding returns the encoding of segwit addresses of the given
+// witness version under BIP350.
+func segwitEncoding(version byte) Encoding {
+	if version == 0 {
+		return Bech32
+	}
+
+	return Bech32m
+}
+
+// checkWitnessProgram verifies the witness version and program length rules
+// of BIP141.
+func checkWitnessProgram(version byte, program []byte) error {
+	if version > 16 {
+		return ErrInvalidWitnessVersion
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return ErrInvalidProgramLength
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return ErrInvalidProgramLength
+	}
+
+	return nil
+}
+
+// EncodeSegwitAddress returns the segwit address of the witness program, with
+// the Bech32 checksum for version 0 and the Bech32m checksum otherwise.
+func EncodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	if err := checkWitnessProgram(version, program); err != nil {
+		return "", err
+	}
+
+	data, err := ConvertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(hrp, append([]byte{version}, data...), segwitEncoding(version))
+}
+
+// DecodeSegwitAddress decodes a segwit address of the given human-readable
+// part and returns its witness version and program.
+func DecodeSegwitAddress(hrp, address string) (version byte, program []byte, err error) {
+	gotHRP, data, enc, err := Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gotHRP != hrp {
+		return 0, nil, ErrHRPMismatch
+	}
+	if len(data) < 1 {
+		return 0, nil, ErrInvalidProgramLength
+	}
+
+	version = data[0]
+	program, err = ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := checkWitnessProgram(version, program); err != nil {
+		return 0, nil, err
+	}
+	if enc != segwitEncoding(version) {
+		return 0, nil, ErrInvalidEncoding
+	}
+
+	return version, program, nil
+}
@@ -0,0 +1,98 @@
+package bech32_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/bech32"
+)
+
+// segwitScript returns the output script of a witness program.
+func segwitScript(version byte, program []byte) string {
+	op := version
+	if version != 0 {
+		op = 0x50 + version
+	}
+
+	return hex.EncodeToString(append([]byte{op, byte(len(program))}, program...))
+}
+
+// TestSegwitAddress verifies the valid segwit address test vectors of BIP350
+// against their output scripts.
+func TestSegwitAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		script  string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", "5128751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BC1SW50QGDZ25J", "6002751e"},
+		{"bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs", "5210751e76e8199196d454941c45d1b3a323"},
+		{"tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy", "0020000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c", "5120000000c4a5cad46221b2a187905e5266362b99d5e91c6ce24d165dab93e86433"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "512079be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+
+	for _, test := range tests {
+		hrp := strings.ToLower(test.address[:2])
+
+		version, program, err := bech32.DecodeSegwitAddress(hrp, test.address)
+		if err != nil {
+			t.Errorf("DecodeSegwitAddress(%s) = err %v", test.address, err)
+			continue
+		}
+		if script := segwitScript(version, program); script != test.script {
+			t.Errorf("DecodeSegwitAddress(%s) = script %s (want: %s)", test.address, script, test.script)
+		}
+
+		address, err := bech32.EncodeSegwitAddress(hrp, version, program)
+		if want := strings.ToLower(test.address); err != nil || address != want {
+			t.Errorf("EncodeSegwitAddress(%s) = %s, %v (want: %s)", test.script, address, err, want)
+		}
+	}
+}
+
+// TestSegwitAddressInvalid verifies the invalid segwit address test vectors
+// of BIP350.
+func TestSegwitAddressInvalid(t *testing.T) {
+	tests := []struct {
+		address string
+		err     error
+	}{
+		{"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut", bech32.ErrHRPMismatch},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", bech32.ErrInvalidEncoding},
+		{"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf", bech32.ErrInvalidEncoding},
+		{"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL", bech32.ErrInvalidEncoding},
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh", bech32.ErrInvalidEncoding},
+		{"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47", bech32.ErrInvalidEncoding},
+		{"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4", bech32.InvalidCharacterError{Char: 'o', Offset: 59}},
+		{"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R", bech32.ErrInvalidWitnessVersion},
+		{"bc1pw5dgrnzv", bech32.ErrInvalidProgramLength},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav", bech32.ErrInvalidProgramLength},
+		{"BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P", bech32.ErrInvalidProgramLength},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq", bech32.ErrMixedCase},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf", bech32.ErrInvalidPadding},
+		{"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j", bech32.ErrInvalidPadding},
+		{"bc1gmk9yu", bech32.ErrInvalidProgramLength},
+	}
+
+	for _, test := range tests {
+		hrp := "bc"
+		if strings.HasPrefix(test.address, "tb") {
+			hrp = "tb"
+		}
+
+		if _, _, err := bech32.DecodeSegwitAddress(hrp, test.address); err != test.err {
+			t.Errorf("DecodeSegwitAddress(%s) = err %v (want: %v)", test.address, err, test.err)
+		}
+	}
+
+	if _, err := bech32.EncodeSegwitAddress("bc", 0, make([]byte, 21)); err != bech32.ErrInvalidProgramLength {
+		t.Errorf("EncodeSegwitAddress(21 bytes v0) = err %v (want: %v)", err, bech32.ErrInvalidProgramLength)
+	}
+	if _, err := bech32.EncodeSegwitAddress("bc", 17, make([]byte, 20)); err != bech32.ErrInvalidWitnessVersion {
+		t.Errorf("EncodeSegwitAddress(v17) = err %v (want: %v)", err, bech32.ErrInvalidWitnessVersion)
+	}
+}
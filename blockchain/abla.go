@@ -0,0 +1,129 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+const (
+	// ablaB7 is the fixed point scale of bchcfg.ABLAConfig.ZetaXB7.
+	ablaB7 = 1 << 7
+
+	// ABLAStateSize is the size in bytes of a serialized ABLAState.
+	ABLAStateSize = 16
+)
+
+// ABLAState is the state of the Adaptive Blocksize Limit Algorithm after a
+// block. The block size limit of the next block is the sum of the control block
+// size and the elastic buffer size.
+type ABLAState struct {
+	ControlBlockSize  uint64
+	ElasticBufferSize uint64
+}
+
+// NewABLAState returns the state of the algorithm at activation, whose block
+// size limit is the default block size of the configuration.
+func NewABLAState(config *bchcfg.ABLAConfig) ABLAState {
+	return ABLAState{
+		ControlBlockSize:  config.Epsilon0,
+		ElasticBufferSize: config.Beta0,
+	}
+}
+
+// BlockSizeLimit returns the maximum size of the block following the block this
+// state was computed for.
+func (s ABLAState) BlockSizeLimit() uint64 {
+	return s.ControlBlockSize + s.ElasticBufferSize
+}
+
+// NextBlockState returns the state after a block of blockSize bytes. The block
+// size is clamped to the current limit.
+func (s ABLAState) NextBlockState(config *bchcfg.ABLAConfig, blockSize uint64) ABLAState {
+	if limit := s.BlockSizeLimit(); blockSize > limit {
+		blockSize = limit
+	}
+
+	var next ABLAState
+
+	// Control function: the control block size moves towards zeta times the
+	// block size, slowed down by the share of the elastic buffer.
+	amplifiedBlockSize := mulDiv(config.ZetaXB7, blockSize, ablaB7)
+	if amplifiedBlockSize > s.ControlBlockSize {
+		bytesToAdd := amplifiedBlockSize - s.ControlBlockSize
+		amplifiedLimit := mulDiv(config.ZetaXB7, s.BlockSizeLimit(), ablaB7)
+		bytesMax := amplifiedLimit - s.ControlBlockSize
+		scalingOffset := mulDiv(mulDiv(config.ZetaXB7, s.ElasticBufferSize, ablaB7), bytesToAdd, bytesMax)
+
+		next.ControlBlockSize = s.ControlBlockSize + (bytesToAdd-scalingOffset)/config.GammaReciprocal
+	} else {
+		bytesToRemove := s.ControlBlockSize - amplifiedBlockSize
+
+		next.ControlBlockSize = s.ControlBlockSize - bytesToRemove/config.GammaReciprocal
+	}
+
+	// Elastic buffer function: the buffer decays every block and grows by
+	// delta times the growth of the control block size.
+	bufferDecay := s.ElasticBufferSize / config.ThetaReciprocal
+	if amplifiedBlockSize > s.ControlBlockSize {
+		bytesToAdd := (next.ControlBlockSize - s.ControlBlockSize) * config.Delta
+
+		next.ElasticBufferSize = s.ElasticBufferSize - bufferDecay + bytesToAdd
+	} else {
+		next.ElasticBufferSize = s.ElasticBufferSize - bufferDecay
+	}
+
+	// The buffer may decay faster than it grows, so both floors apply
+	// whichever way the block moved the state.
+	if next.ControlBlockSize < config.Epsilon0 {
+		next.ControlBlockSize = config.Epsilon0
+	}
+	if next.ElasticBufferSize < config.Beta0 {
+		next.ElasticBufferSize = config.Beta0
+	}
+	if next.ControlBlockSize > config.EpsilonMax {
+		next.ControlBlockSize = config.EpsilonMax
+	}
+	if next.ElasticBufferSize > config.BetaMax {
+		next.ElasticBufferSize = config.BetaMax
+	}
+
+	return next
+}
+
+// Bytes serializes the state as two little endian 64-bit integers.
+func (s ABLAState) Bytes() []byte {
+	b := make([]byte, ABLAStateSize)
+	binary.LittleEndian.PutUint64(b[0:8], s.ControlBlockSize)
+	binary.LittleEndian.PutUint64(b[8:16], s.ElasticBufferSize)
+
+	return b
+}
+
+// SetBytes deserializes the state from its serialized representation.
+func (s *ABLAState) SetBytes(b []byte) error {
+	if len(b) != ABLAStateSize {
+		return fmt.Errorf("invalid abla state length of %v, %v needed", len(b), ABLAStateSize)
+	}
+
+	s.ControlBlockSize = binary.LittleEndian.Uint64(b[0:8])
+	s.ElasticBufferSize = binary.LittleEndian.Uint64(b[8:16])
+
+	return nil
+}
+
+// mulDiv returns x*y/z computed with a 128-bit intermediate product, saturating
+// if the quotient does not fit 64 bits.
+func mulDiv(x, y, z uint64) uint64 {
+	hi, lo := bits.Mul64(x, y)
+	if hi >= z {
+		return math.MaxUint64
+	}
+
+	quo, _ := bits.Div64(hi, lo, z)
+
+	return quo
+}
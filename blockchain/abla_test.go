@@ -0,0 +1,126 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestABLANextBlockState verifies the evolution of the mainnet ABLA state over
+// runs of full, half full and empty blocks, that blocks growing the buffer by
+// less than its decay keep it at its floor, and that a fixed size configuration
+// never grows.
+func TestABLANextBlockState(t *testing.T) {
+	adaptive := bchcfg.NewABLAConfig(32000000, false)
+	fixed := bchcfg.NewABLAConfig(32000000, true)
+
+	repeat := func(size uint64, count int) []uint64 {
+		sizes := make([]uint64, count)
+		for i := range sizes {
+			sizes[i] = size
+		}
+		return sizes
+	}
+
+	tests := []struct {
+		name   string
+		config *bchcfg.ABLAConfig
+		sizes  []uint64
+		want   ABLAState
+	}{
+		{"empty", &adaptive, repeat(0, 4032), ABLAState{16000000, 16000000}},
+		{"one full", &adaptive, repeat(32000000, 1), ABLAState{16000210, 16001679}},
+		{"growth below decay", &adaptive, repeat(10700000, 1), ABLAState{16000000, 16000000}},
+		{"slow growth", &adaptive, repeat(10700000, 4032), ABLAState{16000000, 16000000}},
+		{"full, clamped", &adaptive, repeat(1e12, 4032), ABLAState{16871167, 22657379}},
+		{"half full", &adaptive, repeat(16000000, 4032), ABLAState{16207223, 16354155}},
+		{"full then empty", &adaptive, append(repeat(1e12, 4032), repeat(0, 4032)...), ABLAState{16000000, 20374807}},
+		{"fixed", &fixed, repeat(1e12, 100), ABLAState{16000000, 16000000}},
+	}
+
+	for _, test := range tests {
+		state := NewABLAState(test.config)
+		if limit := state.BlockSizeLimit(); limit != 32000000 {
+			t.Errorf("BlockSizeLimit(%s) = %d (want: %d)", test.name, limit, 32000000)
+		}
+
+		for _, size := range test.sizes {
+			state = state.NextBlockState(test.config, size)
+		}
+
+		if state != test.want {
+			t.Errorf("NextBlockState(%s) = %+v (want: %+v)", test.name, state, test.want)
+		}
+	}
+}
+
+// TestABLAConfig verifies the overflow caps of the adaptive configuration and
+// that every registered network has a valid configuration.
+func TestABLAConfig(t *testing.T) {
+	config := bchcfg.NewABLAConfig(32000000, false)
+	if config.EpsilonMax != 2837960626724546304 || config.BetaMax != 9459868755748488064 {
+		t.Errorf("NewABLAConfig = max %d, %d (want: %d, %d)", config.EpsilonMax, config.BetaMax,
+			uint64(2837960626724546304), uint64(9459868755748488064))
+	}
+
+	nets := []*bchcfg.Params{
+		&bchcfg.MainnetParams, &bchcfg.Testnet3Params, &bchcfg.RegTestnetParams, &bchcfg.SimnetParams,
+		&bchcfg.Testnet4Params, &bchcfg.ScalenetParams, &bchcfg.ChipnetParams,
+	}
+	for _, params := range nets {
+		if err := params.ABLAConfig.Validate(); err != nil {
+			t.Errorf("Validate(%s) = err %v", params.Name, err)
+		}
+
+		state := NewABLAState(&params.ABLAConfig)
+		if state.BlockSizeLimit() != params.DefaultExcessiveBlockSize {
+			t.Errorf("BlockSizeLimit(%s) = %d (want: %d)", params.Name, state.BlockSizeLimit(),
+				params.DefaultExcessiveBlockSize)
+		}
+	}
+
+	invalid := config
+	invalid.ZetaXB7 = 300
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate expected error for zeta above 2")
+	}
+
+	// Zeta must be strictly above 1, which also guards the epsilon check.
+	invalid.ZetaXB7 = 128
+	if err := invalid.Validate(); err == nil {
+		t.Error("Validate expected error for zeta of 1")
+	}
+
+	invalid.ZetaXB7 = 129
+	if err := invalid.Validate(); err != nil {
+		t.Errorf("Validate(zeta 129/128) = err %v", err)
+	}
+}
+
+// TestABLAStateSerialization verifies that states round trip through their
+// serialized representation.
+func TestABLAStateSerialization(t *testing.T) {
+	state := ABLAState{ControlBlockSize: 16871167, ElasticBufferSize: 22657379}
+	want := []byte{
+		0xff, 0x6e, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x63, 0xb9, 0x59, 0x01, 0x00, 0x00, 0x00, 0x00,
+	}
+
+	b := state.Bytes()
+	if !bytes.Equal(b, want) {
+		t.Errorf("Bytes = %x (want: %x)", b, want)
+	}
+
+	var decoded ABLAState
+	if err := decoded.SetBytes(b); err != nil {
+		t.Fatalf("SetBytes = err %v", err)
+	}
+	if decoded != state {
+		t.Errorf("SetBytes = %+v (want: %+v)", decoded, state)
+	}
+
+	if err := decoded.SetBytes(b[:15]); err == nil {
+		t.Error("SetBytes expected error on short input")
+	}
+}
@@ -0,0 +1,54 @@
+package blockchain
+
+import (
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// IsUpgradeEnabled returns whether the rules of an upgrade apply to the block
+// following the block at prevHeight, as defined by Params.IsUpgradeActive. The
+// median time past of the previous block is only computed for time based
+// upgrades.
+func IsUpgradeEnabled(lookup HeaderLookup, params *bchcfg.Params, u bchcfg.Upgrade, prevHeight int32) (bool, error) {
+	var mtp time.Time
+	if u.IsTimeBased() {
+		var err error
+		mtp, err = CalcPastMedianTime(lookup, prevHeight)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return params.IsUpgradeActive(u, prevHeight+1, mtp), nil
+}
+
+// IsUAHFEnabled returns whether the UAHF rules apply to the block following the
+// block at prevHeight.
+func IsUAHFEnabled(params *bchcfg.Params, prevHeight int32) bool {
+	return params.IsUpgradeActive(bchcfg.UpgradeUAHF, prevHeight+1, time.Time{})
+}
+
+// IsDAAEnabled returns whether the cw-144 difficulty adjustment algorithm
+// applies to the block following the block at prevHeight.
+func IsDAAEnabled(params *bchcfg.Params, prevHeight int32) bool {
+	return params.IsUpgradeActive(bchcfg.UpgradeDAA, prevHeight+1, time.Time{})
+}
+
+// IsMagneticAnomalyEnabled returns whether the Magnetic Anomaly rules apply to
+// the block following the block at prevHeight.
+func IsMagneticAnomalyEnabled(params *bchcfg.Params, prevHeight int32) bool {
+	return params.IsUpgradeActive(bchcfg.UpgradeMagneticAnomaly, prevHeight+1, time.Time{})
+}
+
+// IsGreatWallEnabled returns whether the Great Wall rules apply to the block
+// following the block at prevHeight.
+func IsGreatWallEnabled(lookup HeaderLookup, params *bchcfg.Params, prevHeight int32) (bool, error) {
+	return IsUpgradeEnabled(lookup, params, bchcfg.UpgradeGreatWall, prevHeight)
+}
+
+// IsGravitonEnabled returns whether the Graviton rules apply to the block
+// following the block at prevHeight.
+func IsGravitonEnabled(lookup HeaderLookup, params *bchcfg.Params, prevHeight int32) (bool, error) {
+	return IsUpgradeEnabled(lookup, params, bchcfg.UpgradeGraviton, prevHeight)
+}
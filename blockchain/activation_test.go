@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestHeightActivation verifies that height based upgrades apply to the first
+// block after the configured fork height.
+// (Block #478,559 is the first Bitcoin Cash block)
+func TestHeightActivation(t *testing.T) {
+	params := &bchcfg.MainnetParams
+
+	tests := []struct {
+		name      string
+		isEnabled func(*bchcfg.Params, int32) bool
+		height    int32
+	}{
+		{"UAHF", IsUAHFEnabled, 478558},
+		{"DAA", IsDAAEnabled, 504031},
+		{"MagneticAnomaly", IsMagneticAnomalyEnabled, 556766},
+	}
+
+	for _, test := range tests {
+		if test.isEnabled(params, test.height-1) {
+			t.Errorf("Is%sEnabled(%d) = true (want: false)", test.name, test.height-1)
+		}
+		if !test.isEnabled(params, test.height) {
+			t.Errorf("Is%sEnabled(%d) = false (want: true)", test.name, test.height)
+		}
+	}
+}
+
+// TestTimeActivation verifies that time based upgrades apply once the median
+// time past of the parent block reaches the activation time, regardless of the
+// timestamp of the parent itself.
+func TestTimeActivation(t *testing.T) {
+	params := &bchcfg.MainnetParams
+	activation := time.Unix(int64(params.GravitonActivationTime), 0)
+
+	// The median time past of the last block is exactly the activation time,
+	// while the median time past of its parent is one block interval earlier.
+	chain := newTestChain(600000, 20, 0x1804dafe, 600)
+	for i, header := range chain.headers {
+		header.Timestamp = activation.Add(time.Duration(i-14) * 10 * time.Minute)
+	}
+
+	tests := []struct {
+		prevHeight int32
+		want       bool
+	}{
+		{chain.tip() - 1, false},
+		{chain.tip(), true},
+	}
+
+	for _, test := range tests {
+		enabled, err := IsGravitonEnabled(chain, params, test.prevHeight)
+		if err != nil {
+			t.Errorf("IsGravitonEnabled(%d) = err %v", test.prevHeight, err)
+		} else if enabled != test.want {
+			t.Errorf("IsGravitonEnabled(%d) = %t (want: %t)", test.prevHeight, enabled, test.want)
+		}
+
+		enabled, err = IsGreatWallEnabled(chain, params, test.prevHeight)
+		if err != nil || !enabled {
+			t.Errorf("IsGreatWallEnabled(%d) = %t, err %v (want: true)", test.prevHeight, enabled, err)
+		}
+	}
+
+	// Regtest upgrades configured at time zero are always active.
+	enabled, err := IsGravitonEnabled(chain, &bchcfg.RegTestnetParams, chain.tip()-1)
+	if err != nil || !enabled {
+		t.Errorf("IsGravitonEnabled(regtest) = %t, err %v (want: true)", enabled, err)
+	}
+}
@@ -0,0 +1,74 @@
+package blockchain
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// CalcASERTTarget computes the aserti3-2d target of a block from the target of
+// the anchor block. timeDiff is the time elapsed between the parent of the anchor
+// block and the parent of the block, heightDiff the number of blocks between the
+// anchor block and the parent of the block, both in the units of targetSpacing
+// and halfLife (seconds).
+//
+// The target is multiplied by 2^((timeDiff - targetSpacing*(heightDiff+1)) / halfLife)
+// using only integer arithmetic, with the fractional part of the exponent
+// approximated by a cubic polynomial, so the result is exact and reproducible
+// across implementations. The result is clamped to [1, powLimit].
+func CalcASERTTarget(refTarget *big.Int, targetSpacing, timeDiff, heightDiff int64, powLimit *big.Int,
+	halfLife int64) *big.Int {
+
+	// The exponent is a 16.16 fixed point number. Go integer division
+	// truncates towards zero and right shifts of signed integers are
+	// arithmetic, matching the reference implementation.
+	exponent := ((timeDiff - targetSpacing*(heightDiff+1)) * 65536) / halfLife
+
+	shifts := exponent >> 16
+	frac := uint64(uint16(exponent))
+
+	// factor approximates 2^frac * 65536 for frac in [0, 1).
+	factor := 65536 + ((195766423245049*frac + 971821376*frac*frac + 5127*frac*frac*frac + (1 << 47)) >> 48)
+
+	nextTarget := new(big.Int).Mul(refTarget, new(big.Int).SetUint64(factor))
+
+	shifts -= 16
+	if shifts <= 0 {
+		nextTarget.Rsh(nextTarget, uint(-shifts))
+	} else {
+		nextTarget.Lsh(nextTarget, uint(shifts))
+	}
+
+	if nextTarget.Sign() == 0 {
+		return nextTarget.SetInt64(1)
+	}
+	if nextTarget.Cmp(powLimit) > 0 {
+		return nextTarget.Set(powLimit)
+	}
+
+	return nextTarget
+}
+
+// isASERTEnabled returns whether the block following the block at prevHeight
+// has its difficulty computed by ASERT. The anchor block is the last block of
+// the previous algorithm.
+func isASERTEnabled(params *bchcfg.Params, prevHeight int32) bool {
+	return params.ASERTHalfLife > 0 && prevHeight >= params.ASERTAnchorHeight
+}
+
+// calcASERTRequiredDifficulty returns the compact target required for the block
+// following prev using the anchor parameters of the network.
+func calcASERTRequiredDifficulty(params *bchcfg.Params, prev *BlockHeader, prevHeight int32) uint32 {
+
+	refTarget := pow.CompactToBig(params.ASERTAnchorBits)
+	targetSpacing := int64(params.TargetTimePerBlock / time.Second)
+	timeDiff := prev.Timestamp.Unix() - params.ASERTAnchorParentTime
+	heightDiff := int64(prevHeight - params.ASERTAnchorHeight)
+	halfLife := int64(params.ASERTHalfLife / time.Second)
+
+	nextTarget := CalcASERTTarget(refTarget, targetSpacing, timeDiff, heightDiff, params.PowLimit, halfLife)
+
+	return pow.BigToCompact(nextTarget)
+}
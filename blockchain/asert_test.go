@@ -0,0 +1,115 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// TestCalcASERTTarget verifies the integer ASERT calculation on schedule, one
+// half-life ahead and behind, half a half-life ahead (exercising the polynomial
+// approximation), off by a second, and at both clamps.
+func TestCalcASERTTarget(t *testing.T) {
+	powLimit := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 224), big.NewInt(1))
+
+	tests := []struct {
+		refBits    uint32
+		timeDiff   int64
+		heightDiff int64
+		want       uint32
+	}{
+		{0x1804dafe, 600, 0, 0x1804dafe},
+		{0x1804dafe, 173400, 0, 0x1809b5fc},
+		{0x1804dafe, -172200, 0, 0x18026d7f},
+		{0x1804dafe, 87000, 0, 0x1806ddb4},
+		{0x1804dafe, 600600, 1000, 0x1804dafe},
+		{0x1804dafe, 600601, 1000, 0x1804dafe},
+		{0x1804dafe, 600599, 1000, 0x1804dafe},
+		{0x1804dafe, -6912000, 0, 0x1304d806},
+		{0x1804dafe, 6912000, 0, 0x1d00ffff},
+		{0x1d00ffff, 57000, 100, 0x1d00fc56},
+		{0x1a2b3c4d, 12345678, 20000, 0x1b00ac94},
+		{0x1804dafe, 1184400, 2015, 0x1804639d},
+		{0x03000010, -863400, 0, 0x01010000},
+	}
+
+	for x, test := range tests {
+		refTarget := pow.CompactToBig(test.refBits)
+		target := CalcASERTTarget(refTarget, 600, test.timeDiff, test.heightDiff, powLimit, 172800)
+		if bits := pow.BigToCompact(target); bits != test.want {
+			t.Errorf("CalcASERTTarget(%d) = %08x (want: %08x)", x, bits, test.want)
+		}
+	}
+}
+
+// TestASERTAnchor verifies that ASERT takes over from cw-144 after the anchor
+// block of mainnet and testnet3.
+func TestASERTAnchor(t *testing.T) {
+	tests := []struct {
+		params     *bchcfg.Params
+		blocks     int32
+		parentTime int64
+		want       uint32
+	}{
+		// The anchor block itself, mined exactly one block interval after its parent.
+		{&bchcfg.MainnetParams, 1, 600, 0x1804dafe},
+		{&bchcfg.MainnetParams, 11, 11*600 + 1234, 0x1804e12c},
+		{&bchcfg.Testnet3Params, 1000, 1000*600 - 50000, 0x1d00d17c},
+	}
+
+	for x, test := range tests {
+		params := test.params
+		base := params.ASERTAnchorHeight - 1
+		chain := newTestChain(base, int(test.blocks)+1, params.ASERTAnchorBits, 600)
+		for i, header := range chain.headers {
+			header.Timestamp = time.Unix(params.ASERTAnchorParentTime+int64(i)*600, 0)
+		}
+		prev := chain.headers[len(chain.headers)-1]
+		prev.Timestamp = time.Unix(params.ASERTAnchorParentTime+test.parentTime, 0)
+
+		bits, err := CalcNextRequiredDifficulty(chain, params, chain.tip(), prev.Timestamp.Add(10*time.Minute))
+		if err != nil {
+			t.Errorf("CalcNextRequiredDifficulty(%d) = err %v", x, err)
+			continue
+		}
+		if bits != test.want {
+			t.Errorf("CalcNextRequiredDifficulty(%d) = %08x (want: %08x)", x, bits, test.want)
+		}
+	}
+}
+
+// TestMinDifficultyRule verifies that the testnet minimum difficulty rule is
+// checked before the algorithm is chosen, so it applies under ASERT and before
+// the DAA fork alike.
+func TestMinDifficultyRule(t *testing.T) {
+	params := &bchcfg.Testnet3Params
+
+	tests := []struct {
+		name string
+		base int32
+		bits uint32
+	}{
+		{"asert", params.ASERTAnchorHeight, params.ASERTAnchorBits},
+		{"before daa", params.DAAForkHeight - 300, 0x1c0ffff0},
+	}
+
+	for _, test := range tests {
+		chain := newTestChain(test.base, 200, test.bits, 600)
+		last := chain.headers[len(chain.headers)-1].Timestamp
+
+		// At exactly twice the target spacing the rule does not apply yet.
+		bits, err := CalcNextRequiredDifficulty(chain, params, chain.tip(), last.Add(20*time.Minute))
+		if err == nil && bits == params.PowLimitBits {
+			t.Errorf("CalcNextRequiredDifficulty(%s, 20m) = %08x (want: not the pow limit)", test.name, bits)
+		}
+
+		bits, err = CalcNextRequiredDifficulty(chain, params, chain.tip(), last.Add(20*time.Minute+time.Second))
+		if err != nil || bits != params.PowLimitBits {
+			t.Errorf("CalcNextRequiredDifficulty(%s) = %08x, err %v (want: %08x)", test.name, bits, err,
+				params.PowLimitBits)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+const (
+	// cw144WindowSize is the number of blocks over which the cw-144 DAA
+	// measures chain work and elapsed time.
+	cw144WindowSize = 144
+
+	// cw144MinTimespanBlocks and cw144MaxTimespanBlocks clamp the elapsed
+	// time of the window, expressed in block intervals, to limit the
+	// adjustment to a factor of two either way.
+	cw144MinTimespanBlocks = 72
+	cw144MaxTimespanBlocks = 288
+)
+
+var (
+	// oneLsh256 is 1 shifted left 256 bits.
+	oneLsh256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// ErrUnsupportedDifficulty ...
+var ErrUnsupportedDifficulty = errors.New("difficulty adjustment algorithm not supported at this height")
+
+// CalcNextRequiredDifficulty returns the compact target required for the block
+// following the block at prevHeight, given the timestamp of that new block.
+//
+// Blocks after the ASERT anchor block use the aserti3-2d algorithm, blocks after
+// DAAForkHeight the cw-144 algorithm. Earlier algorithms are not supported and an
+// ErrUnsupportedDifficulty error is returned for blocks before DAAForkHeight.
+func CalcNextRequiredDifficulty(lookup HeaderLookup, params *bchcfg.Params, prevHeight int32,
+	newBlockTime time.Time) (uint32, error) {
+
+	prev, err := lookup.HeaderByHeight(prevHeight)
+	if err != nil {
+		return 0, err
+	}
+
+	if params.NoDifficultyAdjustement {
+		return prev.Bits, nil
+	}
+
+	// On test networks a block more than twice the target spacing after its
+	// parent may be mined at the minimum difficulty.
+	if params.ReduceMinDifficulty {
+		reductionTime := prev.Timestamp.Add(params.MinDifficultyReductionTime)
+		if newBlockTime.After(reductionTime) {
+			return params.PowLimitBits, nil
+		}
+	}
+
+	if isASERTEnabled(params, prevHeight) {
+		return calcASERTRequiredDifficulty(params, prev, prevHeight), nil
+	}
+
+	if !IsDAAEnabled(params, prevHeight) {
+		return 0, ErrUnsupportedDifficulty
+	}
+
+	return calcCW144RequiredDifficulty(lookup, params, prevHeight)
+}
+
+// calcCW144RequiredDifficulty implements the cw-144 difficulty adjustment
+// algorithm. The target is derived from the chain work accumulated between two
+// "suitable" blocks 144 blocks apart and the time elapsed between them, each
+// suitable block being the median by timestamp of a block and its two parents.
+func calcCW144RequiredDifficulty(lookup HeaderLookup, params *bchcfg.Params, prevHeight int32) (uint32, error) {
+
+	if prevHeight < cw144WindowSize+2 {
+		return 0, fmt.Errorf("cw-144 needs at least %d blocks, chain has %d", cw144WindowSize+3, prevHeight+1)
+	}
+
+	lastHeight, last, err := suitableBlock(lookup, prevHeight)
+	if err != nil {
+		return 0, err
+	}
+
+	firstHeight, first, err := suitableBlock(lookup, prevHeight-cw144WindowSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Chain work done over the interval, that is the work of every block after
+	// the first suitable block up to and including the last one.
+	work := new(big.Int)
+	for height := firstHeight + 1; height <= lastHeight; height++ {
+		header, err := lookup.HeaderByHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		work.Add(work, pow.CalcWork(header.Bits))
+	}
+
+	targetSpacing := int64(params.TargetTimePerBlock / time.Second)
+
+	timespan := last.Timestamp.Unix() - first.Timestamp.Unix()
+	if timespan > cw144MaxTimespanBlocks*targetSpacing {
+		timespan = cw144MaxTimespanBlocks * targetSpacing
+	} else if timespan < cw144MinTimespanBlocks*targetSpacing {
+		timespan = cw144MinTimespanBlocks * targetSpacing
+	}
+
+	// The projected work per block interval W gives the next target as
+	// (2^256 - W) / W.
+	work.Mul(work, big.NewInt(targetSpacing))
+	work.Div(work, big.NewInt(timespan))
+
+	nextTarget := new(big.Int).Sub(oneLsh256, work)
+	nextTarget.Div(nextTarget, work)
+
+	if nextTarget.Cmp(params.PowLimit) > 0 {
+		return params.PowLimitBits, nil
+	}
+
+	return pow.BigToCompact(nextTarget), nil
+}
+
+// suitableBlock returns the median by timestamp of the block at height and its
+// two parents, which protects the cw-144 algorithm against timestamp skew.
+func suitableBlock(lookup HeaderLookup, height int32) (int32, *BlockHeader, error) {
+	var heights [3]int32
+	var blocks [3]*BlockHeader
+	for i := range blocks {
+		heights[i] = height - 2 + int32(i)
+
+		header, err := lookup.HeaderByHeight(heights[i])
+		if err != nil {
+			return 0, nil, err
+		}
+		blocks[i] = header
+	}
+
+	// Sorting network for three elements.
+	if blocks[0].Timestamp.After(blocks[2].Timestamp) {
+		blocks[0], blocks[2] = blocks[2], blocks[0]
+		heights[0], heights[2] = heights[2], heights[0]
+	}
+	if blocks[0].Timestamp.After(blocks[1].Timestamp) {
+		blocks[0], blocks[1] = blocks[1], blocks[0]
+		heights[0], heights[1] = heights[1], heights[0]
+	}
+	if blocks[1].Timestamp.After(blocks[2].Timestamp) {
+		blocks[1], blocks[2] = blocks[2], blocks[1]
+		heights[1], heights[2] = heights[2], heights[1]
+	}
+
+	return heights[1], blocks[1], nil
+}
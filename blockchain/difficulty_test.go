@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// testChain is a HeaderLookup over a contiguous run of headers starting at
+// height base.
+type testChain struct {
+	base    int32
+	headers []*BlockHeader
+}
+
+func (c *testChain) HeaderByHeight(height int32) (*BlockHeader, error) {
+	i := int(height - c.base)
+	if i < 0 || i >= len(c.headers) {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+
+	return c.headers[i], nil
+}
+
+func (c *testChain) tip() int32 {
+	return c.base + int32(len(c.headers)) - 1
+}
+
+// newTestChain builds a chain of count headers starting at height base, with
+// the passed bits and one block every spacing seconds.
+func newTestChain(base int32, count int, bits uint32, spacing int64) *testChain {
+	c := &testChain{base: base}
+	for i := 0; i < count; i++ {
+		c.headers = append(c.headers, &BlockHeader{
+			Version:   0x20000000,
+			Timestamp: time.Unix(1510600000+int64(i)*spacing, 0),
+			Bits:      bits,
+		})
+	}
+
+	return c
+}
+
+// TestCW144 verifies the cw-144 algorithm against targets computed independently
+// for steady, fast, slow and clamped block production, mixed targets over the
+// window and out of order timestamps around the suitable blocks.
+func TestCW144(t *testing.T) {
+	const base = 504000
+	const count = 200
+
+	tests := []struct {
+		name   string
+		chain  func() *testChain
+		expect uint32
+	}{
+		{"steady", func() *testChain {
+			return newTestChain(base, count, 0x180287d8, 600)
+		}, 0x180287d8},
+		{"twice as fast", func() *testChain {
+			return newTestChain(base, count, 0x180287d8, 300)
+		}, 0x180143ec},
+		{"fast, clamped", func() *testChain {
+			return newTestChain(base, count, 0x180287d8, 100)
+		}, 0x180143ec},
+		{"slow", func() *testChain {
+			return newTestChain(base, count, 0x180287d8, 900)
+		}, 0x1803cbc4},
+		{"slow, clamped", func() *testChain {
+			return newTestChain(base, count, 0x180287d8, 2000)
+		}, 0x18050fb0},
+		{"mixed targets", func() *testChain {
+			c := newTestChain(base, count, 0x180287d8, 600)
+			for i := 0; i < count; i += 2 {
+				c.headers[i].Bits = 0x18031a3d
+			}
+			return c
+		}, 0x1802c99c},
+		{"suitable block medians", func() *testChain {
+			c := newTestChain(base, count, 0x180287d8, 600)
+			c.headers[count-2].Timestamp = c.headers[count-3].Timestamp.Add(4000 * time.Second)
+			c.headers[count-1].Timestamp = c.headers[count-3].Timestamp.Add(5000 * time.Second)
+			c.headers[count-146].Timestamp = c.headers[count-145].Timestamp.Add(50 * time.Second)
+			return c
+		}, 0x1802a184},
+		{"pow limit", func() *testChain {
+			return newTestChain(base, count, 0x1d00ffff, 600000)
+		}, 0x1d00ffff},
+	}
+
+	for _, test := range tests {
+		chain := test.chain()
+		newBlockTime := chain.headers[count-1].Timestamp.Add(10 * time.Minute)
+
+		bits, err := CalcNextRequiredDifficulty(chain, &bchcfg.MainnetParams, chain.tip(), newBlockTime)
+		if err != nil {
+			t.Errorf("CalcNextRequiredDifficulty(%s) = err %v", test.name, err)
+			continue
+		}
+		if bits != test.expect {
+			t.Errorf("CalcNextRequiredDifficulty(%s) = %08x (want: %08x)", test.name, bits, test.expect)
+		}
+	}
+}
+
+// TestCW144Testnet verifies the testnet rule allowing a minimum difficulty block
+// after twice the target spacing without a block.
+func TestCW144Testnet(t *testing.T) {
+	params := bchcfg.Testnet3Params
+	chain := newTestChain(params.DAAForkHeight, 200, 0x1c0ffff0, 600)
+	last := chain.headers[len(chain.headers)-1].Timestamp
+
+	bits, err := CalcNextRequiredDifficulty(chain, &params, chain.tip(), last.Add(20*time.Minute))
+	if err != nil {
+		t.Fatalf("CalcNextRequiredDifficulty = err %v", err)
+	}
+	if bits != 0x1c0ffff0 {
+		t.Errorf("CalcNextRequiredDifficulty = %08x (want: %08x)", bits, 0x1c0ffff0)
+	}
+
+	bits, err = CalcNextRequiredDifficulty(chain, &params, chain.tip(), last.Add(20*time.Minute+time.Second))
+	if err != nil {
+		t.Fatalf("CalcNextRequiredDifficulty = err %v", err)
+	}
+	if bits != params.PowLimitBits {
+		t.Errorf("CalcNextRequiredDifficulty = %08x (want: %08x)", bits, params.PowLimitBits)
+	}
+}
+
+// TestCalcNextRequiredDifficultyErrors verifies that blocks before the DAA fork and
+// chains too short for the cw-144 window are rejected, and that networks without
+// difficulty adjustment keep the previous target.
+func TestCalcNextRequiredDifficultyErrors(t *testing.T) {
+	chain := newTestChain(bchcfg.MainnetParams.DAAForkHeight-199, 200, 0x180287d8, 600)
+	_, err := CalcNextRequiredDifficulty(chain, &bchcfg.MainnetParams, chain.tip()-1, time.Now())
+	if err != ErrUnsupportedDifficulty {
+		t.Errorf("CalcNextRequiredDifficulty = err %v (want: %v)", err, ErrUnsupportedDifficulty)
+	}
+
+	params := bchcfg.SimnetParams
+	params.DAAForkHeight = 0
+	params.NoDifficultyAdjustement = false
+
+	short := newTestChain(0, 100, 0x207fffff, 600)
+	newBlockTime := short.headers[len(short.headers)-1].Timestamp.Add(10 * time.Minute)
+	_, err = CalcNextRequiredDifficulty(short, &params, short.tip(), newBlockTime)
+	if err == nil {
+		t.Error("CalcNextRequiredDifficulty expected error on short chain")
+	}
+
+	bits, err := CalcNextRequiredDifficulty(short, &bchcfg.RegTestnetParams, short.tip(), newBlockTime)
+	if err != nil || bits != 0x207fffff {
+		t.Errorf("CalcNextRequiredDifficulty = %08x, err %v (want: %08x)", bits, err, 0x207fffff)
+	}
+}
@@ -0,0 +1,65 @@
+package blockchain
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+// BlockHeaderSize is the size in bytes of a serialized block header.
+const BlockHeaderSize = 80
+
+// BlockHeader holds the fields of a block header.
+type BlockHeader struct {
+	Version    int32
+	PrevBlock  chainhash.Hash
+	MerkleRoot chainhash.Hash
+	Timestamp  time.Time
+	Bits       uint32
+	Nonce      uint32
+}
+
+// HeaderLookup provides access by height to the headers of the chain being
+// validated. Header-only nodes can implement it over their header store.
+type HeaderLookup interface {
+	// HeaderByHeight returns the header at the passed height, or an error if
+	// the chain does not contain it.
+	HeaderByHeight(height int32) (*BlockHeader, error)
+}
+
+// Bytes serializes the header to its 80 bytes wire representation.
+func (h *BlockHeader) Bytes() []byte {
+	b := make([]byte, BlockHeaderSize)
+
+	binary.LittleEndian.PutUint32(b[0:4], uint32(h.Version))
+	copy(b[4:36], h.PrevBlock[:])
+	copy(b[36:68], h.MerkleRoot[:])
+	binary.LittleEndian.PutUint32(b[68:72], uint32(h.Timestamp.Unix()))
+	binary.LittleEndian.PutUint32(b[72:76], h.Bits)
+	binary.LittleEndian.PutUint32(b[76:80], h.Nonce)
+
+	return b
+}
+
+// SetBytes deserializes the header from its 80 bytes wire representation.
+func (h *BlockHeader) SetBytes(b []byte) error {
+	if len(b) != BlockHeaderSize {
+		return fmt.Errorf("invalid header length of %v, %v needed", len(b), BlockHeaderSize)
+	}
+
+	h.Version = int32(binary.LittleEndian.Uint32(b[0:4]))
+	copy(h.PrevBlock[:], b[4:36])
+	copy(h.MerkleRoot[:], b[36:68])
+	h.Timestamp = time.Unix(int64(binary.LittleEndian.Uint32(b[68:72])), 0)
+	h.Bits = binary.LittleEndian.Uint32(b[72:76])
+	h.Nonce = binary.LittleEndian.Uint32(b[76:80])
+
+	return nil
+}
+
+// BlockHash returns the double SHA256 of the serialized header.
+func (h *BlockHeader) BlockHash() chainhash.Hash {
+	return chainhash.SHA256dToHash(h.Bytes())
+}
@@ -0,0 +1,55 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+// TestBlockHeader verifies header serialization and hashing with the Bitcoin
+// genesis block and block #100,000.
+func TestBlockHeader(t *testing.T) {
+	tests := []struct {
+		version    int32
+		prevBlock  string
+		merkleRoot string
+		timestamp  int64
+		bits       uint32
+		nonce      uint32
+		hash       string
+	}{
+		{1, "0000000000000000000000000000000000000000000000000000000000000000",
+			"4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", 1231006505, 0x1d00ffff, 2083236893,
+			"000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"},
+		{1, "000000000002d01c1fccc21636b607dfd930d31d01c3a62104612a1719011250",
+			"f3e94742aca4b5ef85488dc37c06c3282295ffec960994b2c0d5ac2a25a95766", 1293623863, 0x1b04864c, 274148111,
+			"000000000003ba27aa200b1cecaad478d2b00432346c3f1f3986da1afd33e506"},
+	}
+
+	for x, test := range tests {
+		prevBlock, _ := chainhash.NewHashFromString(test.prevBlock)
+		merkleRoot, _ := chainhash.NewHashFromString(test.merkleRoot)
+
+		header := BlockHeader{
+			Version:    test.version,
+			PrevBlock:  *prevBlock,
+			MerkleRoot: *merkleRoot,
+			Timestamp:  time.Unix(test.timestamp, 0),
+			Bits:       test.bits,
+			Nonce:      test.nonce,
+		}
+
+		if hash := header.BlockHash(); hash.String() != test.hash {
+			t.Errorf("BlockHash(%d) = %s (want: %s)", x, hash, test.hash)
+		}
+
+		var decoded BlockHeader
+		if err := decoded.SetBytes(header.Bytes()); err != nil {
+			t.Errorf("SetBytes(%d) = err %v", x, err)
+		} else if !bytes.Equal(decoded.Bytes(), header.Bytes()) {
+			t.Errorf("SetBytes(%d) = %v (want: %v)", x, decoded, header)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	// medianTimeBlocks is the number of previous blocks used to compute the
+	// median time past.
+	medianTimeBlocks = 11
+
+	// MaxTimeOffset is how far in the future, relative to the local clock, a
+	// header timestamp is allowed to be.
+	MaxTimeOffset = 2 * time.Hour
+)
+
+var (
+	// ErrTimeTooOld ...
+	ErrTimeTooOld = errors.New("block timestamp is not after the median time past")
+
+	// ErrTimeTooNew ...
+	ErrTimeTooNew = errors.New("block timestamp is too far in the future")
+)
+
+// Clock is a source of the current time, allowing the future timestamp check
+// to be driven by a network adjusted or a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is a Clock returning the local system time.
+var SystemClock Clock = systemClock{}
+
+// CalcPastMedianTime returns the median time past of the block at height, that
+// is the median timestamp of the block and its 10 ancestors. Fewer blocks are
+// used near the genesis block.
+func CalcPastMedianTime(lookup HeaderLookup, height int32) (time.Time, error) {
+	timestamps := make([]int64, 0, medianTimeBlocks)
+	for i := int32(0); i < medianTimeBlocks && height-i >= 0; i++ {
+		header, err := lookup.HeaderByHeight(height - i)
+		if err != nil {
+			return time.Time{}, err
+		}
+		timestamps = append(timestamps, header.Timestamp.Unix())
+	}
+
+	if len(timestamps) == 0 {
+		return time.Time{}, errors.New("median time past of a negative height")
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool {
+		return timestamps[i] < timestamps[j]
+	})
+
+	return time.Unix(timestamps[len(timestamps)/2], 0), nil
+}
+
+// CheckHeaderTimestamp checks that the timestamp of a header following the block
+// at prevHeight is after the median time past of that block and no more than
+// MaxTimeOffset ahead of clock.
+func CheckHeaderTimestamp(header *BlockHeader, lookup HeaderLookup, prevHeight int32, clock Clock) error {
+	medianTime, err := CalcPastMedianTime(lookup, prevHeight)
+	if err != nil {
+		return err
+	}
+
+	if !header.Timestamp.After(medianTime) {
+		return ErrTimeTooOld
+	}
+
+	if header.Timestamp.After(clock.Now().Add(MaxTimeOffset)) {
+		return ErrTimeTooNew
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock returning a fixed time.
+type fakeClock time.Time
+
+func (c fakeClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// TestCalcPastMedianTime verifies the median time past over 11 blocks, over the
+// shorter windows available near genesis and with out of order timestamps.
+func TestCalcPastMedianTime(t *testing.T) {
+	chain := newTestChain(0, 20, 0x207fffff, 600)
+	start := chain.headers[0].Timestamp.Unix()
+
+	tests := []struct {
+		height int32
+		want   int64
+	}{
+		{0, start},
+		{1, start + 600},
+		{3, start + 2*600},
+		{10, start + 5*600},
+		{19, start + 14*600},
+	}
+
+	for _, test := range tests {
+		mtp, err := CalcPastMedianTime(chain, test.height)
+		if err != nil {
+			t.Errorf("CalcPastMedianTime(%d) = err %v", test.height, err)
+		} else if mtp.Unix() != test.want {
+			t.Errorf("CalcPastMedianTime(%d) = %d (want: %d)", test.height, mtp.Unix(), test.want)
+		}
+	}
+
+	// Moving the median block far in the future makes the next one the median.
+	chain.headers[14].Timestamp = chain.headers[14].Timestamp.Add(time.Hour)
+	mtp, err := CalcPastMedianTime(chain, 19)
+	if err != nil {
+		t.Fatalf("CalcPastMedianTime = err %v", err)
+	}
+	if want := start + 15*600; mtp.Unix() != want {
+		t.Errorf("CalcPastMedianTime = %d (want: %d)", mtp.Unix(), want)
+	}
+
+	if _, err := CalcPastMedianTime(chain, 20); err == nil {
+		t.Error("CalcPastMedianTime expected error for unknown height")
+	}
+}
+
+// TestCheckHeaderTimestamp verifies the median time past and future time limits.
+func TestCheckHeaderTimestamp(t *testing.T) {
+	chain := newTestChain(0, 20, 0x207fffff, 600)
+	mtp, _ := CalcPastMedianTime(chain, 19)
+	clock := fakeClock(chain.headers[19].Timestamp.Add(10 * time.Minute))
+
+	tests := []struct {
+		timestamp time.Time
+		want      error
+	}{
+		{mtp.Add(-time.Second), ErrTimeTooOld},
+		{mtp, ErrTimeTooOld},
+		{mtp.Add(time.Second), nil},
+		{clock.Now().Add(MaxTimeOffset), nil},
+		{clock.Now().Add(MaxTimeOffset + time.Second), ErrTimeTooNew},
+	}
+
+	for x, test := range tests {
+		header := &BlockHeader{Timestamp: test.timestamp}
+		if err := CheckHeaderTimestamp(header, chain, 19, clock); err != test.want {
+			t.Errorf("CheckHeaderTimestamp(%d) = err %v (want: %v)", x, err, test.want)
+		}
+	}
+}
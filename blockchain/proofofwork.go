@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"errors"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg"
+	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+var (
+	// ErrInvalidTarget ...
+	ErrInvalidTarget = errors.New("block target is not positive")
+
+	// ErrTargetAboveLimit ...
+	ErrTargetAboveLimit = errors.New("block target is above the proof-of-work limit")
+
+	// ErrHighHash ...
+	ErrHighHash = errors.New("block proof-of-work hash is above its target")
+)
+
+// PowHash returns the proof-of-work hash of the header under the given hash
+// function. For SHA256d chains it is the block hash.
+func (h *BlockHeader) PowHash(hasher pow.Hasher) chainhash.Hash {
+	return hasher.PowHash(h.Bytes())
+}
+
+// CheckProofOfWork verifies that the target encoded in the header bits is
+// within the proof-of-work limit of the network, and that the header hashes to
+// a value no greater than that target under the network proof-of-work hash
+// function. It accepts the parameters of any chain package.
+func CheckProofOfWork(header *BlockHeader, params chaincfg.Params) error {
+	target := pow.CompactToBig(header.Bits)
+	if target.Sign() <= 0 {
+		return ErrInvalidTarget
+	}
+	if target.Cmp(params.ProofOfWorkLimit()) > 0 {
+		return ErrTargetAboveLimit
+	}
+
+	hash := header.PowHash(params.ProofOfWorkHasher())
+	if pow.HashToBig(&hash).Cmp(target) > 0 {
+		return ErrHighHash
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/btccfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/xeccfg"
+	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// TestCheckProofOfWork verifies proof-of-work checks with SHA256d and scrypt,
+// using the Bitcoin and Litecoin genesis blocks against the parameters of each
+// chain package.
+func TestCheckProofOfWork(t *testing.T) {
+	header := func(merkleRoot string, timestamp int64, bits, nonce uint32) *BlockHeader {
+		root, _ := chainhash.NewHashFromString(merkleRoot)
+		return &BlockHeader{
+			Version:    1,
+			MerkleRoot: *root,
+			Timestamp:  time.Unix(timestamp, 0),
+			Bits:       bits,
+			Nonce:      nonce,
+		}
+	}
+
+	btcGenesis := header("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b", 1231006505, 0x1d00ffff, 2083236893)
+	ltcGenesis := header("97ddfbbae6be97fd6cdf3e7ca13232a3afff2353e29badfab7f73011edd4ced9", 1317972665, 0x1e0ffff0, 2084524493)
+
+	scryptParams := bchcfg.MainnetParams
+	scryptParams.PowHasher = pow.Scrypt
+	scryptParams.PowLimit = pow.CompactToBig(0x1e0fffff)
+	scryptParams.PowLimitBits = 0x1e0fffff
+
+	sha256dParams := scryptParams
+	sha256dParams.PowHasher = nil
+
+	badNonce := *btcGenesis
+	badNonce.Nonce++
+
+	zeroBits := *btcGenesis
+	zeroBits.Bits = 0
+
+	negativeBits := *btcGenesis
+	negativeBits.Bits = 0x04923456
+
+	tests := []struct {
+		name   string
+		header *BlockHeader
+		params chaincfg.Params
+		err    error
+	}{
+		{"bitcoin genesis", btcGenesis, &bchcfg.MainnetParams, nil},
+		{"bitcoin genesis, btc", btcGenesis, &btccfg.MainnetParams, nil},
+		{"bitcoin genesis, xec", btcGenesis, &xeccfg.MainnetParams, nil},
+		{"bitcoin genesis, btc, bad nonce", &badNonce, &btccfg.MainnetParams, ErrHighHash},
+		{"bitcoin genesis, bad nonce", &badNonce, &bchcfg.MainnetParams, ErrHighHash},
+		{"zero target", &zeroBits, &bchcfg.MainnetParams, ErrInvalidTarget},
+		{"negative target", &negativeBits, &bchcfg.MainnetParams, ErrInvalidTarget},
+		{"litecoin genesis", ltcGenesis, &scryptParams, nil},
+		{"litecoin genesis, sha256d", ltcGenesis, &sha256dParams, ErrHighHash},
+		{"litecoin genesis, bitcoin limit", ltcGenesis, &bchcfg.MainnetParams, ErrTargetAboveLimit},
+		{"litecoin genesis, btc limit", ltcGenesis, &btccfg.MainnetParams, ErrTargetAboveLimit},
+	}
+
+	for _, test := range tests {
+		if err := CheckProofOfWork(test.header, test.params); err != test.err {
+			t.Errorf("CheckProofOfWork(%s) = err %v (want: %v)", test.name, err, test.err)
+		}
+	}
+
+	if hash := btcGenesis.PowHash(pow.SHA256d); hash != btcGenesis.BlockHash() {
+		t.Errorf("PowHash(sha256d) = %v (want: block hash %v)", hash, btcGenesis.BlockHash())
+	}
+}
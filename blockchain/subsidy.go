@@ -0,0 +1,99 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+const (
+	// SatoshiPerBitcoin is the number of satoshis in one bitcoin.
+	SatoshiPerBitcoin = 1e8
+
+	// MaxSatoshi is the maximum number of satoshis that can ever exist.
+	MaxSatoshi = 21e6 * SatoshiPerBitcoin
+
+	// baseSubsidy is the subsidy of the blocks before the first halving.
+	baseSubsidy = 50 * SatoshiPerBitcoin
+)
+
+// ErrBadCoinbaseValue ...
+var ErrBadCoinbaseValue = errors.New("coinbase pays more than the block subsidy and fees")
+
+// CalcBlockSubsidy returns the subsidy in satoshis of the block at height. The
+// subsidy starts at 50 bitcoins and halves every SubsidyReductionInterval blocks.
+func CalcBlockSubsidy(height int32, params *bchcfg.Params) int64 {
+	if height < 0 {
+		return 0
+	}
+	if params.SubsidyReductionInterval == 0 {
+		return baseSubsidy
+	}
+
+	halvings := uint(height / params.SubsidyReductionInterval)
+	if halvings >= 64 {
+		return 0
+	}
+
+	return baseSubsidy >> halvings
+}
+
+// CalcIssuedSupply returns the number of satoshis created by the subsidies of
+// every block from genesis up to and including the block at height. The
+// unspendable genesis coinbase is counted.
+func CalcIssuedSupply(height int32, params *bchcfg.Params) int64 {
+	if height < 0 {
+		return 0
+	}
+
+	interval := int64(params.SubsidyReductionInterval)
+	blocks := int64(height) + 1
+	if interval == 0 {
+		return blocks * baseSubsidy
+	}
+
+	var supply int64
+	for subsidy := int64(baseSubsidy); subsidy > 0 && blocks > 0; subsidy >>= 1 {
+		n := interval
+		if blocks < n {
+			n = blocks
+		}
+		supply += n * subsidy
+		blocks -= n
+	}
+
+	return supply
+}
+
+// SubsidyEndHeight returns the height of the first block without subsidy, or -1
+// if the subsidy of the network never reaches zero.
+func SubsidyEndHeight(params *bchcfg.Params) int32 {
+	if params.SubsidyReductionInterval == 0 {
+		return -1
+	}
+
+	var halvings int32
+	for subsidy := int64(baseSubsidy); subsidy > 0; subsidy >>= 1 {
+		halvings++
+	}
+
+	return halvings * params.SubsidyReductionInterval
+}
+
+// CheckCoinbaseValue checks that the total output value of the coinbase of the
+// block at height does not exceed the block subsidy plus the transaction fees.
+func CheckCoinbaseValue(height int32, coinbaseValue, fees int64, params *bchcfg.Params) error {
+	if coinbaseValue < 0 || coinbaseValue > MaxSatoshi {
+		return fmt.Errorf("coinbase value of %v is out of range", coinbaseValue)
+	}
+	if fees < 0 || fees > MaxSatoshi {
+		return fmt.Errorf("fees of %v are out of range", fees)
+	}
+
+	if coinbaseValue > CalcBlockSubsidy(height, params)+fees {
+		return ErrBadCoinbaseValue
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestCalcBlockSubsidy verifies the subsidy around the mainnet halvings.
+func TestCalcBlockSubsidy(t *testing.T) {
+	tests := []struct {
+		height int32
+		want   int64
+	}{
+		{-1, 0},
+		{0, 5000000000},
+		{209999, 5000000000},
+		{210000, 2500000000},
+		{420000, 1250000000},
+		{630000, 625000000},
+		{840000, 312500000},
+		{6719999, 2},
+		{6720000, 1},
+		{6929999, 1},
+		{6930000, 0},
+		{1 << 30, 0},
+	}
+
+	for _, test := range tests {
+		if subsidy := CalcBlockSubsidy(test.height, &bchcfg.MainnetParams); subsidy != test.want {
+			t.Errorf("CalcBlockSubsidy(%d) = %d (want: %d)", test.height, subsidy, test.want)
+		}
+	}
+}
+
+// TestCalcIssuedSupply verifies the cumulative supply, which tops out at
+// 20,999,999.9769 bitcoins on mainnet.
+func TestCalcIssuedSupply(t *testing.T) {
+	tests := []struct {
+		params *bchcfg.Params
+		height int32
+		want   int64
+	}{
+		{&bchcfg.MainnetParams, -1, 0},
+		{&bchcfg.MainnetParams, 0, 5000000000},
+		{&bchcfg.MainnetParams, 209999, 1050000000000000},
+		{&bchcfg.MainnetParams, 210000, 1050002500000000},
+		{&bchcfg.MainnetParams, 6929999, 2099999997690000},
+		{&bchcfg.MainnetParams, 1 << 30, 2099999997690000},
+		{&bchcfg.RegTestnetParams, 149, 750000000000},
+		{&bchcfg.RegTestnetParams, 1 << 30, 1499999998350},
+	}
+
+	for _, test := range tests {
+		if supply := CalcIssuedSupply(test.height, test.params); supply != test.want {
+			t.Errorf("CalcIssuedSupply(%s, %d) = %d (want: %d)", test.params.Name, test.height, supply, test.want)
+		}
+	}
+}
+
+// TestSubsidyEndHeight verifies the height of the first block without subsidy.
+func TestSubsidyEndHeight(t *testing.T) {
+	for _, params := range []*bchcfg.Params{&bchcfg.MainnetParams, &bchcfg.RegTestnetParams} {
+		height := SubsidyEndHeight(params)
+		if CalcBlockSubsidy(height-1, params) == 0 || CalcBlockSubsidy(height, params) != 0 {
+			t.Errorf("SubsidyEndHeight(%s) = %d", params.Name, height)
+		}
+	}
+
+	if height := SubsidyEndHeight(&bchcfg.MainnetParams); height != 6930000 {
+		t.Errorf("SubsidyEndHeight = %d (want: 6930000)", height)
+	}
+}
+
+// TestCheckCoinbaseValue verifies the coinbase value limit.
+func TestCheckCoinbaseValue(t *testing.T) {
+	params := &bchcfg.MainnetParams
+
+	tests := []struct {
+		height        int32
+		coinbaseValue int64
+		fees          int64
+		wantErr       bool
+	}{
+		{0, 5000000000, 0, false},
+		{0, 5000000001, 0, true},
+		{630000, 625000000 + 1234, 1234, false},
+		{630000, 625000000 + 1235, 1234, true},
+		{630000, -1, 0, true},
+		{630000, 0, -1, true},
+		{6930000, 1234, 1234, false},
+	}
+
+	for x, test := range tests {
+		err := CheckCoinbaseValue(test.height, test.coinbaseValue, test.fees, params)
+		if (err != nil) != test.wantErr {
+			t.Errorf("CheckCoinbaseValue(%d) = err %v (want error: %t)", x, err, test.wantErr)
+		}
+	}
+}
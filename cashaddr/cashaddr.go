@@ -0,0 +1,289 @@
+package cashaddr
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/checksum0/go-cryptoutils/bech32"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// Type is the address type held in bits 3 to 6 of the version byte.
+type Type byte
+
+// Address types.
+const (
+	// P2PKH ...
+	P2PKH Type = 0
+
+	// P2SH ...
+	P2SH Type = 1
+
+	// TokenP2PKH is a P2PKH address of a wallet that accepts CashTokens.
+	TokenP2PKH Type = 2
+
+	// TokenP2SH is a P2SH address of a wallet that accepts CashTokens.
+	TokenP2SH Type = 3
+)
+
+var (
+	// ErrInvalidCharacter is bech32.ErrInvalidCharacter, as cash addresses
+	// share the bech32 character set.
+	ErrInvalidCharacter = bech32.ErrInvalidCharacter
+
+	// ErrMixedCase ...
+	ErrMixedCase = errors.New("cashaddr mixes upper and lower case")
+
+	// ErrInvalidPrefix ...
+	ErrInvalidPrefix = errors.New("invalid cashaddr prefix")
+
+	// ErrPrefixMismatch ...
+	ErrPrefixMismatch = errors.New("cashaddr has an unexpected prefix")
+
+	// ErrUnknownPrefix ...
+	ErrUnknownPrefix = errors.New("cashaddr checksum matches no registered prefix")
+
+	// ErrInvalidChecksum ...
+	ErrInvalidChecksum = errors.New("invalid cashaddr checksum")
+
+	// ErrInvalidPadding ...
+	ErrInvalidPadding = errors.New("invalid cashaddr padding")
+
+	// ErrInvalidVersion ...
+	ErrInvalidVersion = errors.New("cashaddr version byte has its reserved bit set")
+
+	// ErrInvalidType ...
+	ErrInvalidType = errors.New("cashaddr type must be below 16")
+
+	// ErrInvalidHashSize ...
+	ErrInvalidHashSize = errors.New("cashaddr hash size does not match its version byte")
+)
+
+// InvalidCharacterError describes an invalid character and its byte offset in
+// the address. It matches ErrInvalidCharacter with errors.Is.
+type InvalidCharacterError = bech32.InvalidCharacterError
+
+// checksumLen is the number of 5-bit values of the 40-bit checksum.
+const checksumLen = 8
+
+// hashSizes are the hash lengths in bytes selected by the three size bits of
+// the version byte.
+var hashSizes = [8]int{20, 24, 28, 32, 40, 48, 56, 64}
+
+func polymodStep(c uint64, v byte) uint64 {
+	c0 := byte(c >> 35)
+	c = (c&0x07ffffffff)<<5 ^ uint64(v)
+
+	if c0&0x01 != 0 {
+		c ^= 0x98f2bc8e61
+	}
+	if c0&0x02 != 0 {
+		c ^= 0x79b76d99e2
+	}
+	if c0&0x04 != 0 {
+		c ^= 0xf33e5fb3c4
+	}
+	if c0&0x08 != 0 {
+		c ^= 0xae2eabe2a8
+	}
+	if c0&0x10 != 0 {
+		c ^= 0x1e4f43e470
+	}
+
+	return c
+}
+
+// polymod returns the checksum of the lowercase prefix and the 5-bit payload
+// values, which is zero for a valid address.
+func polymod(prefix string, values []byte) uint64 {
+	c := uint64(1)
+	for i := 0; i < len(prefix); i++ {
+		c = polymodStep(c, prefix[i]&0x1f)
+	}
+	c = polymodStep(c, 0)
+	for _, v := range values {
+		c = polymodStep(c, v)
+	}
+
+	return c ^ 1
+}
+
+// Encode returns the lowercase cash address, with its prefix, of the hash of
+// the given type. The hash must be 20, 24, 28, 32, 40, 48, 56 or 64 bytes.
+func Encode(prefix string, typ Type, hash []byte) (string, error) {
+	prefix = strings.ToLower(prefix)
+	if err := checkPrefix(prefix); err != nil {
+		return "", err
+	}
+	if typ >= 16 {
+		return "", ErrInvalidType
+	}
+
+	size := -1
+	for i, n := range hashSizes {
+		if n == len(hash) {
+			size = i
+		}
+	}
+	if size < 0 {
+		return "", ErrInvalidHashSize
+	}
+
+	payload := append([]byte{byte(typ)<<3 | byte(size)}, hash...)
+	values, _ := bech32.ConvertBits(payload, 8, 5, true)
+
+	chk := polymod(prefix, append(values, make([]byte, checksumLen)...))
+
+	var b strings.Builder
+	b.Grow(len(prefix) + 1 + len(values) + checksumLen)
+	b.WriteString(prefix)
+	b.WriteByte(':')
+	for _, v := range values {
+		b.WriteByte(bech32.Charset[v])
+	}
+	for i := 0; i < checksumLen; i++ {
+		b.WriteByte(bech32.Charset[(chk>>uint(5*(checksumLen-1-i)))&0x1f])
+	}
+
+	return b.String(), nil
+}
+
+// DecodePrefix decodes a cash address of the given prefix, which the address
+// may omit. The prefix is case-insensitive.
+func DecodePrefix(address, prefix string) (typ Type, hash []byte, err error) {
+	prefix = strings.ToLower(strings.TrimSuffix(prefix, ":"))
+
+	gotPrefix, payload, err := split(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gotPrefix != "" && gotPrefix != prefix {
+		return 0, nil, ErrPrefixMismatch
+	}
+
+	return decode(prefix, payload, len(address)-len(payload))
+}
+
+// Decode decodes a cash address of a network of the default bchcfg registry.
+// See DecodeRegistry.
+func Decode(address string) (prefix string, typ Type, hash []byte, err error) {
+	return DecodeRegistry(address, bchcfg.DefaultRegistry())
+}
+
+// DecodeRegistry decodes a cash address and returns its lowercase prefix. An
+// address without prefix is checked against the prefixes of the networks of
+// the registry, in registration order, and fails with ErrUnknownPrefix if none
+// matches. An address with a prefix is decoded whether or not the prefix is
+// registered.
+func DecodeRegistry(address string, registry *bchcfg.Registry) (prefix string, typ Type, hash []byte, err error) {
+	prefix, payload, err := split(address)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	if prefix != "" {
+		typ, hash, err = decode(prefix, payload, len(address)-len(payload))
+		return prefix, typ, hash, err
+	}
+
+	seen := make(map[string]bool)
+	for _, params := range registry.Networks() {
+		candidate := strings.ToLower(strings.TrimSuffix(params.CashAddressPrefix, ":"))
+		if candidate == "" || seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+
+		typ, hash, err = decode(candidate, payload, 0)
+		if err == ErrInvalidChecksum {
+			continue
+		}
+		if err != nil {
+			return "", 0, nil, err
+		}
+
+		return candidate, typ, hash, nil
+	}
+
+	return "", 0, nil, ErrUnknownPrefix
+}
+
+// split verifies the characters and the case of the address, and returns its
+// lowercase prefix, empty if omitted, and its payload.
+func split(address string) (prefix, payload string, err error) {
+	var lower, upper bool
+	for i := 0; i < len(address); i++ {
+		c := address[i]
+		if c < 33 || c > 126 {
+			return "", "", InvalidCharacterError{Char: c, Offset: i}
+		}
+		lower = lower || c >= 'a' && c <= 'z'
+		upper = upper || c >= 'A' && c <= 'Z'
+	}
+	if lower && upper {
+		return "", "", ErrMixedCase
+	}
+
+	sep := strings.LastIndexByte(address, ':')
+	if sep < 0 {
+		return "", address, nil
+	}
+
+	prefix = strings.ToLower(address[:sep])
+	if err := checkPrefix(prefix); err != nil {
+		return "", "", err
+	}
+
+	return prefix, address[sep+1:], nil
+}
+
+// checkPrefix verifies that the prefix is made of lowercase letters and
+// digits.
+func checkPrefix(prefix string) error {
+	if prefix == "" {
+		return ErrInvalidPrefix
+	}
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return ErrInvalidPrefix
+		}
+	}
+
+	return nil
+}
+
+// decode decodes the payload of an address under the lowercase prefix. The
+// offset of the payload in the address is used to report invalid characters.
+func decode(prefix, payload string, offset int) (Type, []byte, error) {
+	payload = strings.ToLower(payload)
+	values := make([]byte, len(payload))
+	for i := range values {
+		v := bech32.CharsetIndex(payload[i])
+		if v < 0 {
+			return 0, nil, InvalidCharacterError{Char: payload[i], Offset: offset + i}
+		}
+		values[i] = byte(v)
+	}
+
+	if len(values) <= checksumLen || polymod(prefix, values) != 0 {
+		return 0, nil, ErrInvalidChecksum
+	}
+
+	data, err := bech32.ConvertBits(values[:len(values)-checksumLen], 5, 8, false)
+	if err != nil || len(data) == 0 {
+		return 0, nil, ErrInvalidPadding
+	}
+
+	version := data[0]
+	if version&0x80 != 0 {
+		return 0, nil, ErrInvalidVersion
+	}
+
+	hash := data[1:]
+	if len(hash) != hashSizes[version&0x07] {
+		return 0, nil, ErrInvalidHashSize
+	}
+
+	return Type(version >> 3), hash, nil
+}
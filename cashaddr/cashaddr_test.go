@@ -0,0 +1,149 @@
+package cashaddr_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/cashaddr"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestCashAddr verifies the test vectors of the cashaddr specification.
+func TestCashAddr(t *testing.T) {
+	tests := []struct {
+		address string
+		typ     cashaddr.Type
+		hash    string
+	}{
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", cashaddr.P2PKH, "76a04053bda0a88bda5177b86a15c3b29f559873"},
+		{"bitcoincash:qr95sy3j9xwd2ap32xkykttr4cvcu7as4y0qverfuy", cashaddr.P2PKH, "cb481232299cd5743151ac4b2d63ae198e7bb0a9"},
+		{"bitcoincash:qqq3728yw0y47sqn6l2na30mcw6zm78dzqre909m2r", cashaddr.P2PKH, "011f28e473c95f4013d7d53ec5fbc3b42df8ed10"},
+		{"bitcoincash:ppm2qsznhks23z7629mms6s4cwef74vcwvn0h829pq", cashaddr.P2SH, "76a04053bda0a88bda5177b86a15c3b29f559873"},
+		{"bitcoincash:pr95sy3j9xwd2ap32xkykttr4cvcu7as4yc93ky28e", cashaddr.P2SH, "cb481232299cd5743151ac4b2d63ae198e7bb0a9"},
+		{"bitcoincash:pqq3728yw0y47sqn6l2na30mcw6zm78dzq5ucqzc37", cashaddr.P2SH, "011f28e473c95f4013d7d53ec5fbc3b42df8ed10"},
+		{"bitcoincash:qr6m7j9njldwwzlg9v7v53unlr4jkmx6eylep8ekg2", cashaddr.P2PKH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9"},
+		{"bchtest:pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t", cashaddr.P2SH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9"},
+		{"pref:pr6m7j9njldwwzlg9v7v53unlr4jkmx6ey65nvtks5", cashaddr.P2SH, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9"},
+		{"prefix:0r6m7j9njldwwzlg9v7v53unlr4jkmx6ey3qnjwsrf", 15, "f5bf48b397dae70be82b3cca4793f8eb2b6cdac9"},
+		{"bitcoincash:q9adhakpwzztepkpwp5z0dq62m6u5v5xtyj7j3h2ws4mr9g0", cashaddr.P2PKH, "7adbf6c17084bc86c1706827b41a56f5ca32865925e946ea"},
+		{"bchtest:p9adhakpwzztepkpwp5z0dq62m6u5v5xtyj7j3h2u94tsynr", cashaddr.P2SH, "7adbf6c17084bc86c1706827b41a56f5ca32865925e946ea"},
+		{"pref:p9adhakpwzztepkpwp5z0dq62m6u5v5xtyj7j3h2khlwwk5v", cashaddr.P2SH, "7adbf6c17084bc86c1706827b41a56f5ca32865925e946ea"},
+		{"prefix:09adhakpwzztepkpwp5z0dq62m6u5v5xtyj7j3h2p29kc2lp", 15, "7adbf6c17084bc86c1706827b41a56f5ca32865925e946ea"},
+		{"bitcoincash:qgagf7w02x4wnz3mkwnchut2vxphjzccwxgjvvjmlsxqwkcw59jxxuz", cashaddr.P2PKH, "3a84f9cf51aae98a3bb3a78bf16a6183790b18719126325bfc0c075b"},
+		{"bchtest:pgagf7w02x4wnz3mkwnchut2vxphjzccwxgjvvjmlsxqwkcvs7md7wt", cashaddr.P2SH, "3a84f9cf51aae98a3bb3a78bf16a6183790b18719126325bfc0c075b"},
+		{"pref:pgagf7w02x4wnz3mkwnchut2vxphjzccwxgjvvjmlsxqwkcrsr6gzkn", cashaddr.P2SH, "3a84f9cf51aae98a3bb3a78bf16a6183790b18719126325bfc0c075b"},
+		{"prefix:0gagf7w02x4wnz3mkwnchut2vxphjzccwxgjvvjmlsxqwkc5djw8s9g", 15, "3a84f9cf51aae98a3bb3a78bf16a6183790b18719126325bfc0c075b"},
+		{"bitcoincash:qvch8mmxy0rtfrlarg7ucrxxfzds5pamg73h7370aa87d80gyhqxq5nlegake", cashaddr.P2PKH, "3173ef6623c6b48ffd1a3dcc0cc6489b0a07bb47a37f47cfef4fe69de825c060"},
+		{"bchtest:pvch8mmxy0rtfrlarg7ucrxxfzds5pamg73h7370aa87d80gyhqxq7fqng6m6", cashaddr.P2SH, "3173ef6623c6b48ffd1a3dcc0cc6489b0a07bb47a37f47cfef4fe69de825c060"},
+		{"pref:pvch8mmxy0rtfrlarg7ucrxxfzds5pamg73h7370aa87d80gyhqxq4k9m7qf9", cashaddr.P2SH, "3173ef6623c6b48ffd1a3dcc0cc6489b0a07bb47a37f47cfef4fe69de825c060"},
+		{"prefix:0vch8mmxy0rtfrlarg7ucrxxfzds5pamg73h7370aa87d80gyhqxqsh6jgp6w", 15, "3173ef6623c6b48ffd1a3dcc0cc6489b0a07bb47a37f47cfef4fe69de825c060"},
+	}
+
+	for _, test := range tests {
+		want, _ := hex.DecodeString(test.hash)
+		prefix := test.address[:strings.IndexByte(test.address, ':')]
+
+		for _, address := range []string{test.address, strings.ToUpper(test.address)} {
+			gotPrefix, typ, hash, err := cashaddr.Decode(address)
+			if err != nil {
+				t.Errorf("Decode(%s) = err %v", address, err)
+				continue
+			}
+			if gotPrefix != prefix || typ != test.typ || !bytes.Equal(hash, want) {
+				t.Errorf("Decode(%s) = %s, %d, %x (want: %s, %d, %s)", address, gotPrefix, typ, hash, prefix, test.typ, test.hash)
+			}
+		}
+
+		address, err := cashaddr.Encode(prefix, test.typ, want)
+		if err != nil || address != test.address {
+			t.Errorf("Encode(%s, %d, %s) = %s, %v (want: %s)", prefix, test.typ, test.hash, address, err, test.address)
+		}
+
+		typ, hash, err := cashaddr.DecodePrefix(test.address[len(prefix)+1:], prefix)
+		if err != nil || typ != test.typ || !bytes.Equal(hash, want) {
+			t.Errorf("DecodePrefix(%s) = %d, %x, %v", test.address, typ, hash, err)
+		}
+	}
+}
+
+// TestDecodeRegistry verifies that addresses without prefix are decoded
+// against the prefixes of the registered networks.
+func TestDecodeRegistry(t *testing.T) {
+	tests := []struct {
+		address string
+		prefix  string
+		err     error
+	}{
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash", nil},
+		{"QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A", "bitcoincash", nil},
+		{"pr6m7j9njldwwzlg9v7v53unlr4jkmx6eyvwc0uz5t", "bchtest", nil},
+		{"pr6m7j9njldwwzlg9v7v53unlr4jkmx6ey65nvtks5", "", cashaddr.ErrUnknownPrefix},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q", "", cashaddr.ErrUnknownPrefix},
+		{"qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6A", "", cashaddr.ErrMixedCase},
+	}
+
+	for _, test := range tests {
+		prefix, _, _, err := cashaddr.Decode(test.address)
+		if err != test.err || prefix != test.prefix {
+			t.Errorf("Decode(%s) = %s, %v (want: %s, %v)", test.address, prefix, err, test.prefix, test.err)
+		}
+	}
+
+	registry := bchcfg.NewRegistry()
+	pref := bchcfg.RegTestnetParams
+	pref.Name = "pref"
+	pref.CashAddressPrefix = "pref"
+	if err := registry.Register(&pref); err != nil {
+		t.Fatalf("Register = err %v", err)
+	}
+
+	prefix, typ, _, err := cashaddr.DecodeRegistry("pr6m7j9njldwwzlg9v7v53unlr4jkmx6ey65nvtks5", registry)
+	if err != nil || prefix != "pref" || typ != cashaddr.P2SH {
+		t.Errorf("DecodeRegistry = %s, %d, %v (want: pref, %d, nil)", prefix, typ, err, cashaddr.P2SH)
+	}
+	if _, _, _, err := cashaddr.DecodeRegistry("qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", registry); err != cashaddr.ErrUnknownPrefix {
+		t.Errorf("DecodeRegistry = err %v (want: %v)", err, cashaddr.ErrUnknownPrefix)
+	}
+}
+
+// TestCashAddrInvalid verifies that malformed addresses are rejected.
+func TestCashAddrInvalid(t *testing.T) {
+	tests := []struct {
+		address string
+		prefix  string
+		err     error
+	}{
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bchtest", cashaddr.ErrPrefixMismatch},
+		{"bitcoincash:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A", "bitcoincash", cashaddr.ErrMixedCase},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6q", "bitcoincash", cashaddr.ErrInvalidChecksum},
+		{"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdxoa", "bitcoincash", cashaddr.InvalidCharacterError{Char: 'o', Offset: 52}},
+		{"bitcoin cash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash", cashaddr.InvalidCharacterError{Char: ' ', Offset: 7}},
+		{"bit-coincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash", cashaddr.ErrInvalidPrefix},
+		{":qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", "bitcoincash", cashaddr.ErrInvalidPrefix},
+		{"bitcoincash:qqqqqqqq", "bitcoincash", cashaddr.ErrInvalidChecksum},
+	}
+
+	for _, test := range tests {
+		if _, _, err := cashaddr.DecodePrefix(test.address, test.prefix); err != test.err {
+			t.Errorf("DecodePrefix(%s) = err %v (want: %v)", test.address, err, test.err)
+		}
+	}
+
+	// Hashes of unsupported sizes and types out of range.
+	for _, test := range []struct {
+		hash []byte
+		err  error
+	}{
+		{make([]byte, 21), cashaddr.ErrInvalidHashSize},
+		{nil, cashaddr.ErrInvalidHashSize},
+	} {
+		if _, err := cashaddr.Encode("bitcoincash", cashaddr.P2PKH, test.hash); err != test.err {
+			t.Errorf("Encode(%d bytes) = err %v (want: %v)", len(test.hash), err, test.err)
+		}
+	}
+	if _, err := cashaddr.Encode("bitcoincash", 16, make([]byte, 20)); err != cashaddr.ErrInvalidType {
+		t.Errorf("Encode(type 16) = err %v (want: %v)", err, cashaddr.ErrInvalidType)
+	}
+}
@@ -0,0 +1,96 @@
+package bchcfg
+
+import (
+	"errors"
+	"math"
+)
+
+// ablaB7 is the fixed point scale of ABLAConfig.ZetaXB7.
+const ablaB7 = 1 << 7
+
+// ABLAConfig holds the parameters of the Adaptive Blocksize Limit Algorithm
+// which governs the excessive block size since the May 2024 upgrade.
+type ABLAConfig struct {
+	// Epsilon0 is the initial control block size, also used as its floor.
+	Epsilon0 uint64
+
+	// Beta0 is the initial elastic buffer size, also used as its floor.
+	Beta0 uint64
+
+	// GammaReciprocal is the reciprocal of the control function "forget
+	// factor".
+	GammaReciprocal uint64
+
+	// ZetaXB7 is the control function "asymmetry factor", multiplied by 2^7.
+	ZetaXB7 uint64
+
+	// ThetaReciprocal is the reciprocal of the elastic buffer decay rate.
+	ThetaReciprocal uint64
+
+	// Delta is the elastic buffer "gear factor".
+	Delta uint64
+
+	// EpsilonMax and BetaMax cap the control block size and the elastic
+	// buffer size so that the algorithm never overflows.
+	EpsilonMax uint64
+	BetaMax    uint64
+}
+
+// NewABLAConfig returns the ABLA configuration used by the networks for a
+// default block size, split evenly between the control block size and the
+// elastic buffer. A fixed size configuration never grows past the default.
+func NewABLAConfig(defaultBlockSize uint64, fixedSize bool) ABLAConfig {
+	config := ABLAConfig{
+		Epsilon0:        defaultBlockSize / 2,
+		Beta0:           defaultBlockSize / 2,
+		GammaReciprocal: 37938,
+		ZetaXB7:         192,
+		ThetaReciprocal: 37938,
+		Delta:           10,
+	}
+
+	if fixedSize {
+		config.EpsilonMax = config.Epsilon0
+		config.BetaMax = config.Beta0
+	} else {
+		config.setMax()
+	}
+
+	return config
+}
+
+// setMax sets EpsilonMax and BetaMax to the largest values for which the
+// algorithm cannot overflow, keeping their ratio at the maximum elastic buffer
+// ratio.
+func (c *ABLAConfig) setMax() {
+	maxSafeBlockSizeLimit := math.MaxUint64 / c.ZetaXB7 * ablaB7
+
+	maxRatioNumerator := c.Delta * ((c.ZetaXB7 - ablaB7) * c.ThetaReciprocal / c.GammaReciprocal)
+	maxRatioDenominator := (c.ZetaXB7-ablaB7)*c.ThetaReciprocal/c.GammaReciprocal + ablaB7
+
+	c.EpsilonMax = maxSafeBlockSizeLimit / (maxRatioNumerator + maxRatioDenominator) * maxRatioDenominator
+	c.BetaMax = maxSafeBlockSizeLimit - c.EpsilonMax
+}
+
+// Validate checks that the configuration is within the bounds supported by the
+// algorithm.
+func (c *ABLAConfig) Validate() error {
+	switch {
+	case c.Epsilon0 > c.EpsilonMax:
+		return errors.New("abla: initial control block size above its maximum")
+	case c.Beta0 > c.BetaMax:
+		return errors.New("abla: initial elastic buffer size above its maximum")
+	case c.ZetaXB7 <= ablaB7 || c.ZetaXB7 > 2*ablaB7:
+		return errors.New("abla: zeta out of range (1, 2]")
+	case c.GammaReciprocal < 9484 || c.GammaReciprocal > 151744:
+		return errors.New("abla: gamma reciprocal out of range [9484, 151744]")
+	case c.ThetaReciprocal < 9484 || c.ThetaReciprocal > 151744:
+		return errors.New("abla: theta reciprocal out of range [9484, 151744]")
+	case c.Delta > 32:
+		return errors.New("abla: delta out of range [0, 32]")
+	case c.Epsilon0 < c.GammaReciprocal*ablaB7/(c.ZetaXB7-ablaB7):
+		return errors.New("abla: initial control block size too low for gamma and zeta")
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package bchcfg
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg"
+	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+var _ chaincfg.Params = (*Params)(nil)
+
+// ChainName ...
+func (p *Params) ChainName() string {
+	return chaincfg.ChainBCH
+}
+
+// NetName ...
+func (p *Params) NetName() string {
+	return p.Name
+}
+
+// NetMagic ...
+func (p *Params) NetMagic() uint32 {
+	return uint32(p.Net)
+}
+
+// P2PPort ...
+func (p *Params) P2PPort() string {
+	return p.DefaultPort
+}
+
+// GenesisBlockHash ...
+func (p *Params) GenesisBlockHash() *chainhash.Hash {
+	return p.GenesisHash
+}
+
+// ProofOfWorkLimit ...
+func (p *Params) ProofOfWorkLimit() *big.Int {
+	return p.PowLimit
+}
+
+// ProofOfWorkLimitBits ...
+func (p *Params) ProofOfWorkLimitBits() uint32 {
+	return p.PowLimitBits
+}
+
+// ProofOfWorkHasher returns the PowHasher of the network, defaulting to
+// pow.SHA256d.
+func (p *Params) ProofOfWorkHasher() pow.Hasher {
+	if p.PowHasher == nil {
+		return pow.SHA256d
+	}
+
+	return p.PowHasher
+}
+
+// TargetBlockSpacing ...
+func (p *Params) TargetBlockSpacing() time.Duration {
+	return p.TargetTimePerBlock
+}
+
+// P2PKHAddrID ...
+func (p *Params) P2PKHAddrID() byte {
+	return p.LegacyP2PKHAddrID
+}
+
+// P2SHAddrID ...
+func (p *Params) P2SHAddrID() byte {
+	return p.LegacyP2SHAddrID
+}
+
+// WIFKeyID ...
+func (p *Params) WIFKeyID() byte {
+	return p.PrivateKeyID
+}
+
+// HDPrivateKeyVersion ...
+func (p *Params) HDPrivateKeyVersion() [4]byte {
+	return p.HDPrivateKeyID
+}
+
+// HDPublicKeyVersion ...
+func (p *Params) HDPublicKeyVersion() [4]byte {
+	return p.HDPublicKeyID
+}
+
+// HDCoinTypeIndex ...
+func (p *Params) HDCoinTypeIndex() uint32 {
+	return p.HDCoinType
+}
+
+// CashAddrPrefix ...
+func (p *Params) CashAddrPrefix() string {
+	return p.CashAddressPrefix
+}
+
+// SegwitHRP returns the empty string, Bitcoin Cash has no segwit addresses.
+func (p *Params) SegwitHRP() string {
+	return ""
+}
@@ -0,0 +1,132 @@
+package bchcfg
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+var (
+	// ErrCheckpointMismatch ...
+	ErrCheckpointMismatch = errors.New("block hash does not match checkpoint")
+
+	// ErrForkBeforeCheckpoint ...
+	ErrForkBeforeCheckpoint = errors.New("chain forks before the latest checkpoint")
+)
+
+// ValidateCheckpoints checks that checkpoints have a hash, a non-negative height
+// and are sorted by strictly increasing height.
+func ValidateCheckpoints(checkpoints []Checkpoint) error {
+	for i, checkpoint := range checkpoints {
+		if checkpoint.Hash == nil {
+			return fmt.Errorf("checkpoint at height %d has no hash", checkpoint.Height)
+		}
+		if checkpoint.Height < 0 {
+			return fmt.Errorf("checkpoint at negative height %d", checkpoint.Height)
+		}
+		if i > 0 && checkpoint.Height <= checkpoints[i-1].Height {
+			return fmt.Errorf("checkpoint at height %d follows checkpoint at height %d",
+				checkpoint.Height, checkpoints[i-1].Height)
+		}
+	}
+
+	return nil
+}
+
+// Checkpoints is an immutable set of checkpoints sorted by height, made of the
+// checkpoints of a network and any checkpoints supplied at runtime.
+type Checkpoints struct {
+	list []Checkpoint
+}
+
+// NewCheckpoints returns the checkpoints of params merged with extra ones. An
+// extra checkpoint may repeat a checkpoint of the network but not conflict with
+// it.
+func NewCheckpoints(params *Params, extra ...Checkpoint) (*Checkpoints, error) {
+	if err := ValidateCheckpoints(params.Checkpoints); err != nil {
+		return nil, err
+	}
+
+	byHeight := make(map[int32]Checkpoint, len(params.Checkpoints)+len(extra))
+	for _, checkpoint := range params.Checkpoints {
+		byHeight[checkpoint.Height] = checkpoint
+	}
+
+	for _, checkpoint := range extra {
+		if checkpoint.Hash == nil {
+			return nil, fmt.Errorf("checkpoint at height %d has no hash", checkpoint.Height)
+		}
+		if existing, ok := byHeight[checkpoint.Height]; ok && !existing.Hash.IsEqual(checkpoint.Hash) {
+			return nil, fmt.Errorf("conflicting checkpoints at height %d", checkpoint.Height)
+		}
+		byHeight[checkpoint.Height] = checkpoint
+	}
+
+	list := make([]Checkpoint, 0, len(byHeight))
+	for _, checkpoint := range byHeight {
+		list = append(list, checkpoint)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Height < list[j].Height
+	})
+
+	if err := ValidateCheckpoints(list); err != nil {
+		return nil, err
+	}
+
+	return &Checkpoints{list: list}, nil
+}
+
+// List returns a copy of the checkpoints sorted by height.
+func (c *Checkpoints) List() []Checkpoint {
+	return append([]Checkpoint(nil), c.list...)
+}
+
+// Latest returns the checkpoint with the greatest height at or below height, or
+// nil if there is none.
+func (c *Checkpoints) Latest(height int32) *Checkpoint {
+	i := sort.Search(len(c.list), func(i int) bool {
+		return c.list[i].Height > height
+	})
+	if i == 0 {
+		return nil
+	}
+
+	checkpoint := c.list[i-1]
+	return &checkpoint
+}
+
+// Lookup returns the checkpoint at height, or nil if height is not a checkpoint.
+func (c *Checkpoints) Lookup(height int32) *Checkpoint {
+	checkpoint := c.Latest(height)
+	if checkpoint == nil || checkpoint.Height != height {
+		return nil
+	}
+
+	return checkpoint
+}
+
+// Verify returns ErrCheckpointMismatch if height is a checkpoint whose hash is
+// not hash.
+func (c *Checkpoints) Verify(height int32, hash *chainhash.Hash) error {
+	checkpoint := c.Lookup(height)
+	if checkpoint != nil && !checkpoint.Hash.IsEqual(hash) {
+		return ErrCheckpointMismatch
+	}
+
+	return nil
+}
+
+// CheckFork returns ErrForkBeforeCheckpoint if a chain forking from the best
+// chain at forkHeight, the height of the last common block, would replace a
+// checkpoint at or below tipHeight, the height of the best chain.
+func (c *Checkpoints) CheckFork(tipHeight, forkHeight int32) error {
+	checkpoint := c.Latest(tipHeight)
+	if checkpoint != nil && forkHeight < checkpoint.Height {
+		return ErrForkBeforeCheckpoint
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package bchcfg
+
+import (
+	"testing"
+)
+
+// TestValidateCheckpoints verifies that the checkpoints of the default networks
+// are valid and that unordered checkpoints are rejected.
+func TestValidateCheckpoints(t *testing.T) {
+	for _, params := range defaultNets {
+		if err := ValidateCheckpoints(params.Checkpoints); err != nil {
+			t.Errorf("ValidateCheckpoints(%s) = err %v", params.Name, err)
+		}
+	}
+
+	hash := newHashFromStr("00000000000291ce28027faea320c8d2b054b2e0fe44a773f3eefb151d6bdc97")
+	invalid := [][]Checkpoint{
+		{{Height: 10, Hash: hash}, {Height: 10, Hash: hash}},
+		{{Height: 10, Hash: hash}, {Height: 5, Hash: hash}},
+		{{Height: -1, Hash: hash}},
+		{{Height: 10, Hash: nil}},
+	}
+	for x, checkpoints := range invalid {
+		if err := ValidateCheckpoints(checkpoints); err == nil {
+			t.Errorf("ValidateCheckpoints(%d) expected error", x)
+		}
+	}
+}
+
+// TestCheckpoints verifies lookups, hash verification and fork rejection over the
+// mainnet checkpoints extended with a runtime checkpoint at height 600,000.
+func TestCheckpoints(t *testing.T) {
+	extraHash := newHashFromStr("0000000000000000030fd1c1d5b93c2b8ea40ae67f8c0e8e76e4ab6ae5b2ceb4")
+	checkpoints, err := NewCheckpoints(&MainnetParams, Checkpoint{Height: 600000, Hash: extraHash})
+	if err != nil {
+		t.Fatalf("NewCheckpoints = err %v", err)
+	}
+
+	if n := len(checkpoints.List()); n != len(MainnetParams.Checkpoints)+1 {
+		t.Errorf("List = %d checkpoints (want: %d)", n, len(MainnetParams.Checkpoints)+1)
+	}
+
+	latestTests := []struct {
+		height int32
+		want   int32
+	}{
+		{11110, -1},
+		{11111, 11111},
+		{33332, 11111},
+		{556767, 556767},
+		{599999, 556767},
+		{600000, 600000},
+		{1 << 30, 600000},
+	}
+	for _, test := range latestTests {
+		checkpoint := checkpoints.Latest(test.height)
+		switch {
+		case checkpoint == nil && test.want != -1:
+			t.Errorf("Latest(%d) = nil (want: %d)", test.height, test.want)
+		case checkpoint != nil && checkpoint.Height != test.want:
+			t.Errorf("Latest(%d) = %d (want: %d)", test.height, checkpoint.Height, test.want)
+		}
+	}
+
+	if checkpoints.Lookup(600000) == nil || checkpoints.Lookup(599999) != nil {
+		t.Error("Lookup did not return exactly the checkpoint at height 600000")
+	}
+
+	other := newHashFromStr("000000000000059f452a5f7340de6682a977387c17010ff6e6c3bd83ca8b1317")
+	if err := checkpoints.Verify(600000, extraHash); err != nil {
+		t.Errorf("Verify(600000) = err %v", err)
+	}
+	if err := checkpoints.Verify(600000, other); err != ErrCheckpointMismatch {
+		t.Errorf("Verify(600000) = err %v (want: %v)", err, ErrCheckpointMismatch)
+	}
+	if err := checkpoints.Verify(600001, other); err != nil {
+		t.Errorf("Verify(600001) = err %v", err)
+	}
+
+	if err := checkpoints.CheckFork(650000, 600000); err != nil {
+		t.Errorf("CheckFork(650000, 600000) = err %v", err)
+	}
+	if err := checkpoints.CheckFork(650000, 599999); err != ErrForkBeforeCheckpoint {
+		t.Errorf("CheckFork(650000, 599999) = err %v (want: %v)", err, ErrForkBeforeCheckpoint)
+	}
+	if err := checkpoints.CheckFork(11110, 0); err != nil {
+		t.Errorf("CheckFork(11110, 0) = err %v", err)
+	}
+
+	// A runtime checkpoint may repeat but not contradict a network checkpoint.
+	if _, err := NewCheckpoints(&MainnetParams, MainnetParams.Checkpoints[0]); err != nil {
+		t.Errorf("NewCheckpoints = err %v", err)
+	}
+	if _, err := NewCheckpoints(&MainnetParams, Checkpoint{Height: 11111, Hash: other}); err == nil {
+		t.Error("NewCheckpoints expected error on conflicting checkpoint")
+	}
+}
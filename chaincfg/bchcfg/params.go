@@ -1,13 +1,12 @@
 package bchcfg
 
 import (
-	"errors"
 	"math"
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
 )
 
 const (
@@ -28,22 +27,9 @@ var (
 	regressionPowLimit = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
 	testnet3PowLimit   = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
 	simnetPowLimit     = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 255), bigOne)
-)
-
-var (
-	// ErrDuplicateNet ...
-	ErrDuplicateNet = errors.New("duplicate Bitcoin network")
-
-	// ErrUnknownHDKeyID ...
-	ErrUnknownHDKeyID = errors.New("unknown HD private extended key bytes")
-)
-
-var (
-	registeredNets      = make(map[BitcoinNet]struct{})
-	p2pkhAddrIDs        = make(map[byte]struct{})
-	p2shAddrIDs         = make(map[byte]struct{})
-	cashAddressPrefixes = make(map[string]struct{})
-	hdPrivToPubkeyIDs   = make(map[[4]byte][]byte)
+	testnet4PowLimit   = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
+	scalenetPowLimit   = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
+	chipnetPowLimit    = new(big.Int).Sub(new(big.Int).Lsh(bigOne, 224), bigOne)
 )
 
 // Checkpoint ...
@@ -80,6 +66,13 @@ const (
 
 	// Simnet ...
 	Simnet BitcoinNet = 0x12141c16
+
+	// Testnet4 is also used by chipnet, which only differs from testnet4 by
+	// its port and upgrade schedule.
+	Testnet4 BitcoinNet = 0xafdab7e2
+
+	// Scalenet ...
+	Scalenet BitcoinNet = 0xa2e1afc3
 )
 
 // Params ...
@@ -92,6 +85,7 @@ type Params struct {
 	GenesisHash  *chainhash.Hash
 	PowLimit     *big.Int
 	PowLimitBits uint32
+	PowHasher    pow.Hasher // nil means pow.SHA256d
 
 	BIP0034Height int32
 	BIP0065Height int32
@@ -103,6 +97,20 @@ type Params struct {
 
 	GreatWallActivationTime uint64
 	GravitonActivationTime  uint64
+	PhononActivationTime    uint64
+	AxionActivationTime     uint64
+	Upgrade8ActivationTime  uint64 // May 2022, bigger integers and native introspection
+	Upgrade9ActivationTime  uint64 // May 2023, CashTokens and P2SH32
+	Upgrade10ActivationTime uint64 // May 2024, adaptive blocksize limit algorithm
+	Upgrade11ActivationTime uint64 // May 2025, VM limits and BigInt
+
+	ASERTAnchorHeight     int32
+	ASERTAnchorBits       uint32
+	ASERTAnchorParentTime int64
+	ASERTHalfLife         time.Duration
+
+	DefaultExcessiveBlockSize uint64
+	ABLAConfig                ABLAConfig
 
 	CoinbaseMaturity         uint16
 	SubsidyReductionInterval int32
@@ -151,7 +159,7 @@ var MainnetParams = Params{
 	},
 
 	GenesisBlock:  nil, // TODO
-	GenesisHash:   nil, // TODO
+	GenesisHash:   newHashFromStr("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"),
 	PowLimit:      mainPowLimit,
 	PowLimitBits:  0x1d00ffff,
 	BIP0034Height: 227931,
@@ -164,6 +172,20 @@ var MainnetParams = Params{
 
 	GreatWallActivationTime: 1557921600,
 	GravitonActivationTime:  1573819200,
+	PhononActivationTime:    1589544000,
+	AxionActivationTime:     1605441600,
+	Upgrade8ActivationTime:  1652616000,
+	Upgrade9ActivationTime:  1684152000,
+	Upgrade10ActivationTime: 1715774400,
+	Upgrade11ActivationTime: 1747310400,
+
+	ASERTAnchorHeight:     661647,
+	ASERTAnchorBits:       0x1804dafe,
+	ASERTAnchorParentTime: 1605447844,
+	ASERTHalfLife:         time.Hour * 24 * 2,
+
+	DefaultExcessiveBlockSize: 32000000,
+	ABLAConfig:                NewABLAConfig(32000000, false),
 
 	CoinbaseMaturity:           100,
 	SubsidyReductionInterval:   210000,
@@ -239,7 +261,7 @@ var RegTestnetParams = Params{
 	DNSSeeds:    []DNSSeed{},
 
 	GenesisBlock:  nil, // TODO
-	GenesisHash:   nil, // TODO
+	GenesisHash:   newHashFromStr("0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"),
 	PowLimit:      regressionPowLimit,
 	PowLimitBits:  0x207fffff,
 	BIP0034Height: 100000000,
@@ -250,6 +272,15 @@ var RegTestnetParams = Params{
 	DAAForkHeight:             0,
 	MagneticAnomalyForkHeight: 1000,
 
+	// Difficulty adjustment is disabled, no ASERT anchor block.
+	ASERTAnchorHeight:     0,
+	ASERTAnchorBits:       0,
+	ASERTAnchorParentTime: 0,
+	ASERTHalfLife:         0,
+
+	DefaultExcessiveBlockSize: 32000000,
+	ABLAConfig:                NewABLAConfig(32000000, false),
+
 	CoinbaseMaturity:           100,
 	SubsidyReductionInterval:   150,
 	TargetTimespan:             time.Hour * 24 * 14,
@@ -305,7 +336,7 @@ var Testnet3Params = Params{
 	},
 
 	GenesisBlock:  nil, // TODO
-	GenesisHash:   nil, // TODO
+	GenesisHash:   newHashFromStr("000000000933ea01ad0ee984209779baaec3ced90fa3f408719526f8d77f4943"),
 	PowLimit:      testnet3PowLimit,
 	PowLimitBits:  0x1d00ffff,
 	BIP0034Height: 21111,
@@ -318,6 +349,20 @@ var Testnet3Params = Params{
 
 	GreatWallActivationTime: 1557921600,
 	GravitonActivationTime:  1573819200,
+	PhononActivationTime:    1589544000,
+	AxionActivationTime:     1605441600,
+	Upgrade8ActivationTime:  1652616000,
+	Upgrade9ActivationTime:  1684152000,
+	Upgrade10ActivationTime: 1715774400,
+	Upgrade11ActivationTime: 1747310400,
+
+	ASERTAnchorHeight:     1421481,
+	ASERTAnchorBits:       0x1d00ffff,
+	ASERTAnchorParentTime: 1605445400,
+	ASERTHalfLife:         time.Hour * 24 * 2,
+
+	DefaultExcessiveBlockSize: 32000000,
+	ABLAConfig:                NewABLAConfig(32000000, false),
 
 	CoinbaseMaturity:           100,
 	SubsidyReductionInterval:   210000,
@@ -391,6 +436,15 @@ var SimnetParams = Params{
 	DAAForkHeight:             2000,
 	MagneticAnomalyForkHeight: 3000,
 
+	// Difficulty adjustment is disabled, no ASERT anchor block.
+	ASERTAnchorHeight:     0,
+	ASERTAnchorBits:       0,
+	ASERTAnchorParentTime: 0,
+	ASERTHalfLife:         0,
+
+	DefaultExcessiveBlockSize: 32000000,
+	ABLAConfig:                NewABLAConfig(32000000, false),
+
 	CoinbaseMaturity:           100,
 	SubsidyReductionInterval:   210000,
 	TargetTimespan:             time.Hour * 24 * 14,
@@ -432,69 +486,262 @@ var SimnetParams = Params{
 	HDCoinType: 115,
 }
 
-// String ...
-func (d DNSSeed) String() string {
-	return d.Host
-}
+// Testnet4Params ...
+var Testnet4Params = Params{
+	Name:        "testnet4",
+	Net:         Testnet4,
+	DefaultPort: "28333",
+	DNSSeeds: []DNSSeed{
+		{"testnet4-seed-bch.bitcoinforks.org", true},
+		{"testnet4-seed-bch.toom.im", true},
+		{"seed.tbch4.loping.net", true},
+		{"testnet4-seed.flowee.cash", true},
+	},
 
-// Register ...
-func Register(params *Params) error {
-	if _, ok := registeredNets[params.Net]; ok {
-		return ErrDuplicateNet
-	}
+	GenesisBlock:  nil, // TODO
+	GenesisHash:   newHashFromStr("000000001dd410c49a788668ce26751718cc797474d3152a5fc073dd44fd9f7b"),
+	PowLimit:      testnet4PowLimit,
+	PowLimitBits:  0x1d00ffff,
+	BIP0034Height: 2,
+	BIP0065Height: 3,
+	BIP0066Height: 4,
+
+	UAHFForkHeight:            5,
+	DAAForkHeight:             3000,
+	MagneticAnomalyForkHeight: 3999,
+
+	// Upgrades older than the network are active from genesis.
+	GreatWallActivationTime: 0,
+	GravitonActivationTime:  0,
+	PhononActivationTime:    0,
+	AxionActivationTime:     1605441600,
+	Upgrade8ActivationTime:  1652616000,
+	Upgrade9ActivationTime:  1684152000,
+	Upgrade10ActivationTime: 1715774400,
+	Upgrade11ActivationTime: 1747310400,
+
+	ASERTAnchorHeight:     16844,
+	ASERTAnchorBits:       0x1d00ffff,
+	ASERTAnchorParentTime: 1605451779,
+	ASERTHalfLife:         time.Hour,
+
+	DefaultExcessiveBlockSize: 2000000,
+	ABLAConfig:                NewABLAConfig(2000000, false),
+
+	CoinbaseMaturity:           100,
+	SubsidyReductionInterval:   210000,
+	TargetTimespan:             time.Hour * 24 * 14,
+	TargetTimePerBlock:         time.Minute * 10,
+	RetargetAdjustementFactor:  4,
+	ReduceMinDifficulty:        true,
+	NoDifficultyAdjustement:    false,
+	MinDifficultyReductionTime: time.Minute * 20,
+	GenerateSupported:          false,
+
+	Checkpoints: []Checkpoint{
+		{Height: 0, Hash: newHashFromStr("000000001dd410c49a788668ce26751718cc797474d3152a5fc073dd44fd9f7b")},
+	},
 
-	registeredNets[params.Net] = struct{}{}
-	p2pkhAddrIDs[params.LegacyP2PKHAddrID] = struct{}{}
-	p2shAddrIDs[params.LegacyP2SHAddrID] = struct{}{}
-	hdPrivToPubkeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID[:]
+	RuleChangeActivationThreshold: 1512,
+	MinerConfirmationWindow:       2016,
+	Deployments: [DefinedDeployments]ConsensusDeployment{
+		DeploymentTestDummy: {
+			BitNumber:  28,
+			StartTime:  1199145601, // January 1, 2008 UTC
+			ExpireTime: 1230767999, // December 31, 2008 UTC
+		},
+		DeploymentCSV: {
+			BitNumber:  0,
+			StartTime:  1462060800, // May 1st, 2016
+			ExpireTime: 1493596800, // May 1st, 2017
+		},
+	},
 
-	cashAddressPrefixes[params.CashAddressPrefix+":"] = struct{}{}
+	RelayNonSTDTxs: true,
 
-	return nil
-}
+	CashAddressPrefix: "bchtest",
 
-func mustRegister(params *Params) {
-	err := Register(params)
-	if err != nil {
-		panic("failed to register network: " + err.Error())
-	}
-}
+	LegacyP2PKHAddrID: 0x6f,
+	LegacyP2SHAddrID:  0xc4,
+	PrivateKeyID:      0xef,
 
-// IsP2PKHAddrID ...
-func IsP2PKHAddrID(id byte) bool {
-	_, ok := p2pkhAddrIDs[id]
-	return ok
-}
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf},
 
-// IsP2SHAddrID ...
-func IsP2SHAddrID(id byte) bool {
-	_, ok := p2shAddrIDs[id]
-	return ok
+	HDCoinType: 1,
 }
 
-// IsCashAddressPrefix ...
-func IsCashAddressPrefix(prefix string) bool {
-	prefix = strings.ToLower(prefix)
+// ScalenetParams ...
+var ScalenetParams = Params{
+	Name:        "scalenet",
+	Net:         Scalenet,
+	DefaultPort: "38333",
+	DNSSeeds: []DNSSeed{
+		{"scalenet-seed-bch.bitcoinforks.org", true},
+		{"scalenet-seed-bch.toom.im", true},
+		{"seed.sbch.loping.net", true},
+	},
+
+	GenesisBlock:  nil, // TODO
+	GenesisHash:   newHashFromStr("00000000e6453dc2dfe1ffa19023f86002eb11dbb8e87d0291a4599f0430be52"),
+	PowLimit:      scalenetPowLimit,
+	PowLimitBits:  0x1d00ffff,
+	BIP0034Height: 2,
+	BIP0065Height: 3,
+	BIP0066Height: 4,
+
+	UAHFForkHeight:            5,
+	DAAForkHeight:             3000,
+	MagneticAnomalyForkHeight: 3999,
+
+	// Upgrades older than the network are active from genesis.
+	GreatWallActivationTime: 0,
+	GravitonActivationTime:  0,
+	PhononActivationTime:    0,
+	AxionActivationTime:     1605441600,
+	Upgrade8ActivationTime:  1652616000,
+	Upgrade9ActivationTime:  1684152000,
+	Upgrade10ActivationTime: 1715774400,
+	Upgrade11ActivationTime: 1747310400,
+
+	ASERTAnchorHeight:     16868,
+	ASERTAnchorBits:       0x1d00ffff,
+	ASERTAnchorParentTime: 1605452167,
+	ASERTHalfLife:         time.Hour * 24 * 2,
+
+	DefaultExcessiveBlockSize: 256000000,
+	ABLAConfig:                NewABLAConfig(256000000, false),
+
+	CoinbaseMaturity:           100,
+	SubsidyReductionInterval:   210000,
+	TargetTimespan:             time.Hour * 24 * 14,
+	TargetTimePerBlock:         time.Minute * 10,
+	RetargetAdjustementFactor:  4,
+	ReduceMinDifficulty:        true,
+	NoDifficultyAdjustement:    false,
+	MinDifficultyReductionTime: time.Minute * 20,
+	GenerateSupported:          false,
+
+	Checkpoints: []Checkpoint{
+		{Height: 0, Hash: newHashFromStr("00000000e6453dc2dfe1ffa19023f86002eb11dbb8e87d0291a4599f0430be52")},
+	},
+
+	RuleChangeActivationThreshold: 1512,
+	MinerConfirmationWindow:       2016,
+	Deployments: [DefinedDeployments]ConsensusDeployment{
+		DeploymentTestDummy: {
+			BitNumber:  28,
+			StartTime:  1199145601, // January 1, 2008 UTC
+			ExpireTime: 1230767999, // December 31, 2008 UTC
+		},
+		DeploymentCSV: {
+			BitNumber:  0,
+			StartTime:  1462060800, // May 1st, 2016
+			ExpireTime: 1493596800, // May 1st, 2017
+		},
+	},
+
+	RelayNonSTDTxs: true,
+
+	CashAddressPrefix: "bchtest",
 
-	_, ok := cashAddressPrefixes[prefix]
-	return ok
+	LegacyP2PKHAddrID: 0x6f,
+	LegacyP2SHAddrID:  0xc4,
+	PrivateKeyID:      0xef,
+
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf},
+
+	HDCoinType: 1,
 }
 
-// HDPrivatekeyToPublickeyID ...
-func HDPrivatekeyToPublickeyID(id []byte) ([]byte, error) {
-	if len(id) != 4 {
-		return nil, ErrUnknownHDKeyID
-	}
+// ChipnetParams ...
+var ChipnetParams = Params{
+	Name:        "chipnet",
+	Net:         Testnet4,
+	DefaultPort: "48333",
+	DNSSeeds: []DNSSeed{
+		{"chipnet.imaginary.cash", true},
+		{"chipnet.bitjson.com", true},
+	},
 
-	var key [4]byte
-	copy(key[:], id)
+	GenesisBlock:  nil, // TODO
+	GenesisHash:   newHashFromStr("000000001dd410c49a788668ce26751718cc797474d3152a5fc073dd44fd9f7b"),
+	PowLimit:      chipnetPowLimit,
+	PowLimitBits:  0x1d00ffff,
+	BIP0034Height: 2,
+	BIP0065Height: 3,
+	BIP0066Height: 4,
+
+	UAHFForkHeight:            5,
+	DAAForkHeight:             3000,
+	MagneticAnomalyForkHeight: 3999,
+
+	// Chipnet shares its early history with testnet4 and activates each
+	// upgrade from 2023 six months before mainnet.
+	GreatWallActivationTime: 0,
+	GravitonActivationTime:  0,
+	PhononActivationTime:    0,
+	AxionActivationTime:     1605441600,
+	Upgrade8ActivationTime:  1652616000,
+	Upgrade9ActivationTime:  1668513600,
+	Upgrade10ActivationTime: 1700049600,
+	Upgrade11ActivationTime: 1731672000,
+
+	ASERTAnchorHeight:     16844,
+	ASERTAnchorBits:       0x1d00ffff,
+	ASERTAnchorParentTime: 1605451779,
+	ASERTHalfLife:         time.Hour,
+
+	DefaultExcessiveBlockSize: 2000000,
+	ABLAConfig:                NewABLAConfig(2000000, false),
 
-	pubBytes, ok := hdPrivToPubkeyIDs[key]
-	if !ok {
-		return nil, ErrUnknownHDKeyID
-	}
+	CoinbaseMaturity:           100,
+	SubsidyReductionInterval:   210000,
+	TargetTimespan:             time.Hour * 24 * 14,
+	TargetTimePerBlock:         time.Minute * 10,
+	RetargetAdjustementFactor:  4,
+	ReduceMinDifficulty:        true,
+	NoDifficultyAdjustement:    false,
+	MinDifficultyReductionTime: time.Minute * 20,
+	GenerateSupported:          false,
+
+	Checkpoints: []Checkpoint{
+		{Height: 0, Hash: newHashFromStr("000000001dd410c49a788668ce26751718cc797474d3152a5fc073dd44fd9f7b")},
+	},
+
+	RuleChangeActivationThreshold: 1512,
+	MinerConfirmationWindow:       2016,
+	Deployments: [DefinedDeployments]ConsensusDeployment{
+		DeploymentTestDummy: {
+			BitNumber:  28,
+			StartTime:  1199145601, // January 1, 2008 UTC
+			ExpireTime: 1230767999, // December 31, 2008 UTC
+		},
+		DeploymentCSV: {
+			BitNumber:  0,
+			StartTime:  1462060800, // May 1st, 2016
+			ExpireTime: 1493596800, // May 1st, 2017
+		},
+	},
+
+	RelayNonSTDTxs: true,
+
+	CashAddressPrefix: "bchtest",
 
-	return pubBytes, nil
+	LegacyP2PKHAddrID: 0x6f,
+	LegacyP2SHAddrID:  0xc4,
+	PrivateKeyID:      0xef,
+
+	HDPrivateKeyID: [4]byte{0x04, 0x35, 0x83, 0x94},
+	HDPublicKeyID:  [4]byte{0x04, 0x35, 0x87, 0xcf},
+
+	HDCoinType: 1,
+}
+
+// String ...
+func (d DNSSeed) String() string {
+	return d.Host
 }
 
 func newHashFromStr(s string) *chainhash.Hash {
@@ -505,10 +752,3 @@ func newHashFromStr(s string) *chainhash.Hash {
 
 	return hash
 }
-
-func init() {
-	mustRegister(&MainnetParams)
-	mustRegister(&Testnet3Params)
-	mustRegister(&RegTestnetParams)
-	mustRegister(&SimnetParams)
-}
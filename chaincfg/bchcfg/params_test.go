@@ -0,0 +1,76 @@
+package bchcfg
+
+import (
+	"testing"
+)
+
+var defaultNets = []*Params{
+	&MainnetParams,
+	&Testnet3Params,
+	&RegTestnetParams,
+	&SimnetParams,
+	&Testnet4Params,
+	&ScalenetParams,
+	&ChipnetParams,
+}
+
+// TestRegisterDuplicate verifies that every default network is registered and
+// cannot be registered twice.
+func TestRegisterDuplicate(t *testing.T) {
+	for _, params := range defaultNets {
+		if err := Register(params); err != ErrDuplicateNet {
+			t.Errorf("Register(%s) = err %v (want: %v)", params.Name, err, ErrDuplicateNet)
+		}
+
+		if !IsP2PKHAddrID(params.LegacyP2PKHAddrID) {
+			t.Errorf("IsP2PKHAddrID(%s) = false (want: true)", params.Name)
+		}
+		if !IsP2SHAddrID(params.LegacyP2SHAddrID) {
+			t.Errorf("IsP2SHAddrID(%s) = false (want: true)", params.Name)
+		}
+		if !IsCashAddressPrefix(params.CashAddressPrefix + ":") {
+			t.Errorf("IsCashAddressPrefix(%s) = false (want: true)", params.Name)
+		}
+	}
+}
+
+// TestNetworkCollisions verifies that no two default networks share a name or a
+// default port, and that chipnet, which reuses the network magic of testnet4,
+// is the only exception to magic uniqueness among them.
+func TestNetworkCollisions(t *testing.T) {
+	for i, a := range defaultNets {
+		for _, b := range defaultNets[i+1:] {
+			if a.Name == b.Name {
+				t.Errorf("%s and %s share their name", a.Name, b.Name)
+			}
+			if a.DefaultPort == b.DefaultPort {
+				t.Errorf("%s and %s share default port %s", a.Name, b.Name, a.DefaultPort)
+			}
+			if a.Net == b.Net && !isSharedNetMagic(a, b) {
+				t.Errorf("%s and %s share network magic %08x", a.Name, b.Name, uint32(a.Net))
+			}
+		}
+	}
+}
+
+// TestGenesisCheckpoint verifies that every default network but simnet has a
+// genesis hash, matching its checkpoint at height 0 when it has one.
+func TestGenesisCheckpoint(t *testing.T) {
+	for _, params := range defaultNets {
+		if params.GenesisHash == nil && params != &SimnetParams {
+			t.Errorf("%s: GenesisHash is nil", params.Name)
+		}
+	}
+
+	for _, params := range defaultNets {
+		if params.GenesisHash == nil {
+			continue
+		}
+		for _, checkpoint := range params.Checkpoints {
+			if checkpoint.Height == 0 && !checkpoint.Hash.IsEqual(params.GenesisHash) {
+				t.Errorf("%s: genesis hash %v does not match checkpoint %v", params.Name, params.GenesisHash,
+					checkpoint.Hash)
+			}
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package bchcfg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// ErrUnknownParamsFormat ...
+var ErrUnknownParamsFormat = errors.New("unknown network parameters file format")
+
+// paramsFile is the on-disk representation of Params shared by the JSON and
+// TOML formats. Hashes, byte strings and big integers are hex encoded and
+// durations use the time.Duration string syntax.
+type paramsFile struct {
+	Name         string `json:"name"`
+	Net          uint32 `json:"net"`
+	DefaultPort  string `json:"default_port"`
+	GenesisBlock string `json:"genesis_block,omitempty"`
+	GenesisHash  string `json:"genesis_hash,omitempty"`
+	PowLimit     string `json:"pow_limit"`
+	PowLimitBits uint32 `json:"pow_limit_bits"`
+	PowHasher    string `json:"pow_hasher,omitempty"`
+
+	BIP0034Height int32 `json:"bip0034_height"`
+	BIP0065Height int32 `json:"bip0065_height"`
+	BIP0066Height int32 `json:"bip0066_height"`
+
+	UAHFForkHeight            int32 `json:"uahf_fork_height"`
+	DAAForkHeight             int32 `json:"daa_fork_height"`
+	MagneticAnomalyForkHeight int32 `json:"magnetic_anomaly_fork_height"`
+
+	GreatWallActivationTime uint64 `json:"great_wall_activation_time"`
+	GravitonActivationTime  uint64 `json:"graviton_activation_time"`
+	PhononActivationTime    uint64 `json:"phonon_activation_time"`
+	AxionActivationTime     uint64 `json:"axion_activation_time"`
+	Upgrade8ActivationTime  uint64 `json:"upgrade8_activation_time"`
+	Upgrade9ActivationTime  uint64 `json:"upgrade9_activation_time"`
+	Upgrade10ActivationTime uint64 `json:"upgrade10_activation_time"`
+	Upgrade11ActivationTime uint64 `json:"upgrade11_activation_time"`
+
+	ASERTAnchorHeight     int32  `json:"asert_anchor_height"`
+	ASERTAnchorBits       uint32 `json:"asert_anchor_bits"`
+	ASERTAnchorParentTime int64  `json:"asert_anchor_parent_time"`
+	ASERTHalfLife         string `json:"asert_half_life"`
+
+	DefaultExcessiveBlockSize uint64 `json:"default_excessive_block_size"`
+
+	CoinbaseMaturity         uint16 `json:"coinbase_maturity"`
+	SubsidyReductionInterval int32  `json:"subsidy_reduction_interval"`
+
+	TargetTimespan             string `json:"target_timespan"`
+	TargetTimePerBlock         string `json:"target_time_per_block"`
+	RetargetAdjustementFactor  int64  `json:"retarget_adjustement_factor"`
+	ReduceMinDifficulty        bool   `json:"reduce_min_difficulty"`
+	NoDifficultyAdjustement    bool   `json:"no_difficulty_adjustement"`
+	MinDifficultyReductionTime string `json:"min_difficulty_reduction_time"`
+	GenerateSupported          bool   `json:"generate_supported"`
+
+	RuleChangeActivationThreshold uint32 `json:"rule_change_activation_threshold"`
+	MinerConfirmationWindow       uint32 `json:"miner_confirmation_window"`
+
+	RelayNonSTDTxs bool `json:"relay_non_std_txs"`
+
+	CashAddressPrefix string `json:"cash_address_prefix"`
+
+	LegacyP2PKHAddrID byte `json:"legacy_p2pkh_addr_id"`
+	LegacyP2SHAddrID  byte `json:"legacy_p2sh_addr_id"`
+
+	PrivateKeyID byte `json:"private_key_id"`
+
+	HDPrivateKeyID string `json:"hd_private_key_id"`
+	HDPublicKeyID  string `json:"hd_public_key_id"`
+
+	HDCoinType uint32 `json:"hd_coin_type"`
+
+	ABLAConfig ablaConfigFile `json:"abla"`
+
+	DNSSeeds    []dnsSeedFile    `json:"dns_seeds,omitempty"`
+	Checkpoints []checkpointFile `json:"checkpoints,omitempty"`
+	Deployments []deploymentFile `json:"deployments"`
+}
+
+type ablaConfigFile struct {
+	Epsilon0        uint64 `json:"epsilon0"`
+	Beta0           uint64 `json:"beta0"`
+	GammaReciprocal uint64 `json:"gamma_reciprocal"`
+	ZetaXB7         uint64 `json:"zeta_xb7"`
+	ThetaReciprocal uint64 `json:"theta_reciprocal"`
+	Delta           uint64 `json:"delta"`
+	EpsilonMax      uint64 `json:"epsilon_max"`
+	BetaMax         uint64 `json:"beta_max"`
+}
+
+type dnsSeedFile struct {
+	Host         string `json:"host"`
+	HasFiltering bool   `json:"has_filtering"`
+}
+
+type checkpointFile struct {
+	Height int32  `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+type deploymentFile struct {
+	BitNumber  uint8  `json:"bit_number"`
+	StartTime  uint64 `json:"start_time"`
+	ExpireTime uint64 `json:"expire_time"`
+}
+
+func newParamsFile(p *Params) *paramsFile {
+	f := &paramsFile{
+		Name:                          p.Name,
+		Net:                           uint32(p.Net),
+		DefaultPort:                   p.DefaultPort,
+		GenesisBlock:                  hex.EncodeToString(p.GenesisBlock),
+		PowLimitBits:                  p.PowLimitBits,
+		BIP0034Height:                 p.BIP0034Height,
+		BIP0065Height:                 p.BIP0065Height,
+		BIP0066Height:                 p.BIP0066Height,
+		UAHFForkHeight:                p.UAHFForkHeight,
+		DAAForkHeight:                 p.DAAForkHeight,
+		MagneticAnomalyForkHeight:     p.MagneticAnomalyForkHeight,
+		GreatWallActivationTime:       p.GreatWallActivationTime,
+		GravitonActivationTime:        p.GravitonActivationTime,
+		PhononActivationTime:          p.PhononActivationTime,
+		AxionActivationTime:           p.AxionActivationTime,
+		Upgrade8ActivationTime:        p.Upgrade8ActivationTime,
+		Upgrade9ActivationTime:        p.Upgrade9ActivationTime,
+		Upgrade10ActivationTime:       p.Upgrade10ActivationTime,
+		Upgrade11ActivationTime:       p.Upgrade11ActivationTime,
+		ASERTAnchorHeight:             p.ASERTAnchorHeight,
+		ASERTAnchorBits:               p.ASERTAnchorBits,
+		ASERTAnchorParentTime:         p.ASERTAnchorParentTime,
+		ASERTHalfLife:                 p.ASERTHalfLife.String(),
+		DefaultExcessiveBlockSize:     p.DefaultExcessiveBlockSize,
+		CoinbaseMaturity:              p.CoinbaseMaturity,
+		SubsidyReductionInterval:      p.SubsidyReductionInterval,
+		TargetTimespan:                p.TargetTimespan.String(),
+		TargetTimePerBlock:            p.TargetTimePerBlock.String(),
+		RetargetAdjustementFactor:     p.RetargetAdjustementFactor,
+		ReduceMinDifficulty:           p.ReduceMinDifficulty,
+		NoDifficultyAdjustement:       p.NoDifficultyAdjustement,
+		MinDifficultyReductionTime:    p.MinDifficultyReductionTime.String(),
+		GenerateSupported:             p.GenerateSupported,
+		RuleChangeActivationThreshold: p.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:       p.MinerConfirmationWindow,
+		RelayNonSTDTxs:                p.RelayNonSTDTxs,
+		CashAddressPrefix:             p.CashAddressPrefix,
+		LegacyP2PKHAddrID:             p.LegacyP2PKHAddrID,
+		LegacyP2SHAddrID:              p.LegacyP2SHAddrID,
+		PrivateKeyID:                  p.PrivateKeyID,
+		HDPrivateKeyID:                hex.EncodeToString(p.HDPrivateKeyID[:]),
+		HDPublicKeyID:                 hex.EncodeToString(p.HDPublicKeyID[:]),
+		HDCoinType:                    p.HDCoinType,
+		ABLAConfig:                    ablaConfigFile(p.ABLAConfig),
+	}
+
+	if p.GenesisHash != nil {
+		f.GenesisHash = p.GenesisHash.String()
+	}
+	if p.PowLimit != nil {
+		f.PowLimit = p.PowLimit.Text(16)
+	}
+	if p.PowHasher != nil {
+		f.PowHasher = p.PowHasher.Name()
+	}
+
+	for _, seed := range p.DNSSeeds {
+		f.DNSSeeds = append(f.DNSSeeds, dnsSeedFile(seed))
+	}
+	for _, checkpoint := range p.Checkpoints {
+		var hash string
+		if checkpoint.Hash != nil {
+			hash = checkpoint.Hash.String()
+		}
+		f.Checkpoints = append(f.Checkpoints, checkpointFile{checkpoint.Height, hash})
+	}
+	for _, deployment := range p.Deployments {
+		f.Deployments = append(f.Deployments, deploymentFile(deployment))
+	}
+
+	return f
+}
+
+func (f *paramsFile) params() (*Params, error) {
+	p := &Params{
+		Name:                          f.Name,
+		Net:                           BitcoinNet(f.Net),
+		DefaultPort:                   f.DefaultPort,
+		DNSSeeds:                      make([]DNSSeed, 0, len(f.DNSSeeds)),
+		PowLimitBits:                  f.PowLimitBits,
+		BIP0034Height:                 f.BIP0034Height,
+		BIP0065Height:                 f.BIP0065Height,
+		BIP0066Height:                 f.BIP0066Height,
+		UAHFForkHeight:                f.UAHFForkHeight,
+		DAAForkHeight:                 f.DAAForkHeight,
+		MagneticAnomalyForkHeight:     f.MagneticAnomalyForkHeight,
+		GreatWallActivationTime:       f.GreatWallActivationTime,
+		GravitonActivationTime:        f.GravitonActivationTime,
+		PhononActivationTime:          f.PhononActivationTime,
+		AxionActivationTime:           f.AxionActivationTime,
+		Upgrade8ActivationTime:        f.Upgrade8ActivationTime,
+		Upgrade9ActivationTime:        f.Upgrade9ActivationTime,
+		Upgrade10ActivationTime:       f.Upgrade10ActivationTime,
+		Upgrade11ActivationTime:       f.Upgrade11ActivationTime,
+		ASERTAnchorHeight:             f.ASERTAnchorHeight,
+		ASERTAnchorBits:               f.ASERTAnchorBits,
+		ASERTAnchorParentTime:         f.ASERTAnchorParentTime,
+		DefaultExcessiveBlockSize:     f.DefaultExcessiveBlockSize,
+		ABLAConfig:                    ABLAConfig(f.ABLAConfig),
+		CoinbaseMaturity:              f.CoinbaseMaturity,
+		SubsidyReductionInterval:      f.SubsidyReductionInterval,
+		RetargetAdjustementFactor:     f.RetargetAdjustementFactor,
+		ReduceMinDifficulty:           f.ReduceMinDifficulty,
+		NoDifficultyAdjustement:       f.NoDifficultyAdjustement,
+		GenerateSupported:             f.GenerateSupported,
+		RuleChangeActivationThreshold: f.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:       f.MinerConfirmationWindow,
+		RelayNonSTDTxs:                f.RelayNonSTDTxs,
+		CashAddressPrefix:             f.CashAddressPrefix,
+		LegacyP2PKHAddrID:             f.LegacyP2PKHAddrID,
+		LegacyP2SHAddrID:              f.LegacyP2SHAddrID,
+		PrivateKeyID:                  f.PrivateKeyID,
+		HDCoinType:                    f.HDCoinType,
+	}
+
+	var err error
+	if f.GenesisBlock != "" {
+		if p.GenesisBlock, err = hex.DecodeString(f.GenesisBlock); err != nil {
+			return nil, fmt.Errorf("genesis_block: %v", err)
+		}
+	}
+	if f.GenesisHash != "" {
+		if p.GenesisHash, err = chainhash.NewHashFromString(f.GenesisHash); err != nil {
+			return nil, fmt.Errorf("genesis_hash: %v", err)
+		}
+	}
+	if p.PowLimit, err = parseHexBig(f.PowLimit); err != nil {
+		return nil, fmt.Errorf("pow_limit: %v", err)
+	}
+	if f.PowHasher != "" {
+		if p.PowHasher, err = pow.HasherByName(f.PowHasher); err != nil {
+			return nil, fmt.Errorf("pow_hasher: %v", err)
+		}
+	}
+
+	durations := []struct {
+		name string
+		src  string
+		dst  *time.Duration
+	}{
+		{"asert_half_life", f.ASERTHalfLife, &p.ASERTHalfLife},
+		{"target_timespan", f.TargetTimespan, &p.TargetTimespan},
+		{"target_time_per_block", f.TargetTimePerBlock, &p.TargetTimePerBlock},
+		{"min_difficulty_reduction_time", f.MinDifficultyReductionTime, &p.MinDifficultyReductionTime},
+	}
+	for _, d := range durations {
+		if d.src == "" {
+			continue
+		}
+		if *d.dst, err = time.ParseDuration(d.src); err != nil {
+			return nil, fmt.Errorf("%s: %v", d.name, err)
+		}
+	}
+
+	if p.HDPrivateKeyID, err = parseHDKeyID(f.HDPrivateKeyID); err != nil {
+		return nil, fmt.Errorf("hd_private_key_id: %v", err)
+	}
+	if p.HDPublicKeyID, err = parseHDKeyID(f.HDPublicKeyID); err != nil {
+		return nil, fmt.Errorf("hd_public_key_id: %v", err)
+	}
+
+	for _, seed := range f.DNSSeeds {
+		p.DNSSeeds = append(p.DNSSeeds, DNSSeed(seed))
+	}
+	for i, checkpoint := range f.Checkpoints {
+		hash, err := chainhash.NewHashFromString(checkpoint.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("checkpoints[%d]: %v", i, err)
+		}
+		p.Checkpoints = append(p.Checkpoints, Checkpoint{checkpoint.Height, hash})
+	}
+
+	if len(f.Deployments) != DefinedDeployments {
+		return nil, fmt.Errorf("deployments: got %d entries, want %d", len(f.Deployments), DefinedDeployments)
+	}
+	for i, deployment := range f.Deployments {
+		p.Deployments[i] = ConsensusDeployment(deployment)
+	}
+
+	if errs := p.Validate(); len(errs) > 0 {
+		return nil, &ValidationError{Name: p.Name, Errors: errs}
+	}
+
+	return p, nil
+}
+
+func parseHexBig(s string) (*big.Int, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+
+	n, ok := new(big.Int).SetString(digits, 16)
+	if !ok || digits == "" || digits[0] == '-' || digits[0] == '+' {
+		return nil, fmt.Errorf("invalid hex integer %q", s)
+	}
+
+	return n, nil
+}
+
+func parseHDKeyID(s string) ([4]byte, error) {
+	var id [4]byte
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return id, err
+	}
+	if len(b) != len(id) {
+		return id, fmt.Errorf("got %d bytes, want %d", len(b), len(id))
+	}
+	copy(id[:], b)
+
+	return id, nil
+}
+
+// DecodeParamsJSON reads network parameters from a JSON document.
+func DecodeParamsJSON(r io.Reader) (*Params, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	var f paramsFile
+	if err := dec.Decode(&f); err != nil {
+		return nil, err
+	}
+
+	return f.params()
+}
+
+// DecodeParamsTOML reads network parameters from a TOML document, in the keys
+// of the JSON format. Only the subset of TOML written by EncodeParamsTOML is
+// supported: bare keys, single-line basic and literal strings, decimal and
+// hexadecimal integers, booleans, and non-nested tables and arrays of tables.
+// Inline arrays and tables, dotted and quoted keys, multi-line strings, floats
+// and dates are rejected with an error naming the feature.
+func DecodeParamsTOML(r io.Reader) (*Params, error) {
+	doc, err := decodeTOML(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeParamsJSON(bytes.NewReader(b))
+}
+
+// EncodeParamsJSON writes network parameters as an indented JSON document.
+func EncodeParamsJSON(w io.Writer, params *Params) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(newParamsFile(params))
+}
+
+// EncodeParamsTOML writes network parameters as a TOML document.
+func EncodeParamsTOML(w io.Writer, params *Params) error {
+	return encodeTOML(w, newParamsFile(params))
+}
+
+// ReadParamsFile decodes network parameters from a file, choosing the format
+// from its .json or .toml extension.
+func ReadParamsFile(path string) (*Params, error) {
+	var decode func(io.Reader) (*Params, error)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decode = DecodeParamsJSON
+	case ".toml":
+		decode = DecodeParamsTOML
+	default:
+		return nil, ErrUnknownParamsFormat
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	params, err := decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return params, nil
+}
+
+// LoadParamsFile decodes network parameters from a file and registers them.
+func (r *Registry) LoadParamsFile(path string) (*Params, error) {
+	params, err := ReadParamsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Register(params); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// LoadParamsFile decodes network parameters from a file and registers them in
+// the default registry.
+func LoadParamsFile(path string) (*Params, error) {
+	return defaultRegistry.LoadParamsFile(path)
+}
@@ -0,0 +1,227 @@
+package bchcfg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// TestParamsFileRoundTrip verifies that every default network survives an
+// export and import in both formats.
+func TestParamsFileRoundTrip(t *testing.T) {
+	formats := []struct {
+		name   string
+		encode func(*bytes.Buffer, *Params) error
+		decode func(*bytes.Buffer) (*Params, error)
+	}{
+		{
+			"json",
+			func(b *bytes.Buffer, p *Params) error { return EncodeParamsJSON(b, p) },
+			func(b *bytes.Buffer) (*Params, error) { return DecodeParamsJSON(b) },
+		},
+		{
+			"toml",
+			func(b *bytes.Buffer, p *Params) error { return EncodeParamsTOML(b, p) },
+			func(b *bytes.Buffer) (*Params, error) { return DecodeParamsTOML(b) },
+		},
+	}
+
+	scryptNet := RegTestnetParams
+	scryptNet.Name = "scryptregtest"
+	scryptNet.PowHasher = pow.Scrypt
+	nets := append(defaultNets[:len(defaultNets):len(defaultNets)], &scryptNet)
+
+	for _, format := range formats {
+		for _, params := range nets {
+			var buf bytes.Buffer
+			if err := format.encode(&buf, params); err != nil {
+				t.Errorf("%s: encode %s: unexpected error %v", format.name, params.Name, err)
+				continue
+			}
+
+			decoded, err := format.decode(&buf)
+			if err != nil {
+				t.Errorf("%s: decode %s: unexpected error %v", format.name, params.Name, err)
+				continue
+			}
+
+			if !reflect.DeepEqual(decoded, params) {
+				t.Errorf("%s: %s does not round trip:\n got: %+v\nwant: %+v", format.name, params.Name, decoded, params)
+			}
+		}
+	}
+}
+
+const customTOML = `# A private chain with every upgrade active from genesis.
+name = "privnet"
+net = 0xdab5bffa
+default_port = "19444"
+genesis_hash = "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206"
+pow_limit = "0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"
+pow_limit_bits = 0x207fffff
+target_timespan = "336h"
+target_time_per_block = "10m"
+asert_half_life = "1h"
+asert_anchor_bits = 0x207fffff
+retarget_adjustement_factor = 4
+reduce_min_difficulty = true
+min_difficulty_reduction_time = "20m"
+rule_change_activation_threshold = 108
+miner_confirmation_window = 144
+cash_address_prefix = 'bchpriv'
+legacy_p2pkh_addr_id = 111
+legacy_p2sh_addr_id = 196
+private_key_id = 239
+hd_private_key_id = "04358394"
+hd_public_key_id = "043587cf"
+hd_coin_type = 1
+
+[abla]
+epsilon0 = 1_000_000
+beta0 = 1_000_000
+gamma_reciprocal = 37938
+zeta_xb7 = 192
+theta_reciprocal = 37938
+delta = 10
+epsilon_max = 1000000
+beta_max = 1000000
+
+[[checkpoints]]
+height = 0
+hash = "0f9188f13cb7b2c71f2a335e3a4fc328bf5beb436012afca590b1a11466e2206" # genesis
+
+[[deployments]]
+bit_number = 28
+start_time = 0
+expire_time = 9223372036854775807
+
+[[deployments]]
+bit_number = 0
+start_time = 0
+expire_time = 9223372036854775807
+`
+
+// TestDecodeParamsTOML verifies that a hand written TOML file is decoded and
+// registered.
+func TestDecodeParamsTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "privnet.toml")
+	if err := os.WriteFile(path, []byte(customTOML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry()
+	params, err := registry.LoadParamsFile(path)
+	if err != nil {
+		t.Fatalf("LoadParamsFile: unexpected error %v", err)
+	}
+
+	if params.Net != 0xdab5bffa {
+		t.Errorf("Net = %08x (want: dab5bffa)", uint32(params.Net))
+	}
+	if params.PowLimit.Cmp(regressionPowLimit) != 0 {
+		t.Errorf("PowLimit = %x (want: %x)", params.PowLimit, regressionPowLimit)
+	}
+	if params.ASERTHalfLife != time.Hour || params.TargetTimespan != 14*24*time.Hour {
+		t.Errorf("durations = %v, %v (want: 1h, 336h)", params.ASERTHalfLife, params.TargetTimespan)
+	}
+	if params.ABLAConfig.Epsilon0 != 1000000 {
+		t.Errorf("ABLAConfig.Epsilon0 = %d (want: 1000000)", params.ABLAConfig.Epsilon0)
+	}
+	if len(params.Checkpoints) != 1 || !params.Checkpoints[0].Hash.IsEqual(params.GenesisHash) {
+		t.Errorf("Checkpoints = %v (want: genesis only)", params.Checkpoints)
+	}
+	if params.Deployments[DeploymentTestDummy].BitNumber != 28 {
+		t.Errorf("Deployments[DeploymentTestDummy].BitNumber = %d (want: 28)", params.Deployments[DeploymentTestDummy].BitNumber)
+	}
+
+	if !registry.IsCashAddressPrefix("bchpriv") {
+		t.Errorf("IsCashAddressPrefix(bchpriv) = false (want: true)")
+	}
+	if IsCashAddressPrefix("bchpriv") {
+		t.Errorf("default registry knows bchpriv (want: only the explicit registry)")
+	}
+
+	if _, err := registry.LoadParamsFile(path); err != ErrDuplicateNet {
+		t.Errorf("LoadParamsFile twice = err %v (want: %v)", err, ErrDuplicateNet)
+	}
+}
+
+// TestDecodeParamsErrors verifies that malformed documents are rejected.
+func TestDecodeParamsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+	}{
+		{"unknown key", `hd_coin_type = 1`, `hd_coin_type = 1` + "\n" + `coin = 1`},
+		{"bad hash", `genesis_hash = "0f91`, `genesis_hash = "zz91`},
+		{"bad pow limit", `pow_limit = "0x7f`, `pow_limit = "-0x7f`},
+		{"bad duration", `"336h"`, `"two weeks"`},
+		{"bad hd key id", `"043587cf"`, `"043587"`},
+		{"missing name", `name = "privnet"`, ``},
+		{"missing deployment", `[[deployments]]` + "\nbit_number = 28", `[deployment]` + "\nbit_number = 28"},
+		{"duplicate key", `hd_coin_type = 1`, `hd_coin_type = 1` + "\n" + `hd_coin_type = 2`},
+		{"float", `delta = 10`, `delta = 1.5`},
+		{"unterminated string", `'bchpriv'`, `'bchpriv`},
+		{"bad abla", `delta = 10`, `delta = 33`},
+		{"unknown pow hasher", `hd_coin_type = 1`, `hd_coin_type = 1` + "\n" + `pow_hasher = "x11"`},
+	}
+
+	for _, test := range tests {
+		doc := strings.Replace(customTOML, test.old, test.new, 1)
+		if doc == customTOML {
+			t.Fatalf("%s: replacement %q not found", test.name, test.old)
+		}
+
+		if _, err := DecodeParamsTOML(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: DecodeParamsTOML = nil error (want: error)", test.name)
+		}
+	}
+
+	if _, err := ReadParamsFile("privnet.yaml"); err != ErrUnknownParamsFormat {
+		t.Errorf("ReadParamsFile(privnet.yaml) = err %v (want: %v)", err, ErrUnknownParamsFormat)
+	}
+}
+
+// TestDecodeParamsTOMLUnsupported verifies that valid TOML outside of the
+// supported subset is rejected with an error naming the feature and its line.
+func TestDecodeParamsTOMLUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{"inline array", `delta = 10`, `delta = [10]`, "line 31: key \"delta\": inline arrays are not supported"},
+		{"multi-line array", `delta = 10`, "delta = [\n  10,\n]", "line 31: key \"delta\": inline arrays are not supported"},
+		{"inline table", `delta = 10`, `delta = { value = 10 }`, "inline tables are not supported"},
+		{"dotted key", `delta = 10`, `abla.delta = 10`, "line 31: dotted keys are not supported"},
+		{"quoted key", `delta = 10`, `"delta" = 10`, "quoted keys are not supported"},
+		{"dotted table", `[abla]`, `[params.abla]`, "line 25: table name: dotted keys are not supported"},
+		{"multi-line basic string", `name = "privnet"`, `name = """privnet"""`, "multi-line strings are not supported"},
+		{"multi-line literal string", `name = "privnet"`, "name = '''\nprivnet'''", "multi-line strings are not supported"},
+		{"float", `delta = 10`, `delta = 1.5`, "floats are not supported"},
+		{"float exponent", `delta = 10`, `delta = 1e1`, "floats are not supported"},
+		{"infinity", `delta = 10`, `delta = inf`, "floats are not supported"},
+		{"date", `delta = 10`, `delta = 1979-05-27`, "dates and times are not supported"},
+		{"time", `delta = 10`, `delta = 07:32:00`, "dates and times are not supported"},
+	}
+
+	for _, test := range tests {
+		doc := strings.Replace(customTOML, test.old, test.new, 1)
+		if doc == customTOML {
+			t.Fatalf("%s: replacement %q not found", test.name, test.old)
+		}
+
+		_, err := DecodeParamsTOML(strings.NewReader(doc))
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: DecodeParamsTOML = err %v (want: %q)", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package bchcfg
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrDuplicateNet ...
+	ErrDuplicateNet = errors.New("duplicate Bitcoin network")
+
+	// ErrUnknownNet ...
+	ErrUnknownNet = errors.New("unknown Bitcoin network")
+
+	// ErrUnknownHDKeyID ...
+	ErrUnknownHDKeyID = errors.New("unknown HD private extended key bytes")
+)
+
+// defaultRegistry backs the package-level registry functions.
+var defaultRegistry = NewDefaultRegistry()
+
+// Registry is a set of networks safe for concurrent use. Networks are kept in
+// registration order so that lookups by a key several networks share (testnet3,
+// regtest and the newer test networks all use the same address bytes) are
+// deterministic.
+type Registry struct {
+	mtx   sync.RWMutex
+	nets  map[string]*Params
+	order []*Params
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		nets: make(map[string]*Params),
+	}
+}
+
+// NewDefaultRegistry returns a registry holding the networks defined by this
+// package. It is independent from the default registry.
+func NewDefaultRegistry() *Registry {
+	r := NewRegistry()
+	r.mustRegister(&MainnetParams)
+	r.mustRegister(&Testnet3Params)
+	r.mustRegister(&RegTestnetParams)
+	r.mustRegister(&SimnetParams)
+	r.mustRegister(&Testnet4Params)
+	r.mustRegister(&ScalenetParams)
+	r.mustRegister(&ChipnetParams)
+
+	return r
+}
+
+// DefaultRegistry returns the registry used by the package-level functions.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a network to the registry. A network whose name or network
+// magic is already registered is rejected with ErrDuplicateNet, except for
+// chipnet and testnet4 which share their magic. Parameters failing Validate are
+// rejected with a *ValidationError.
+func (r *Registry) Register(params *Params) error {
+	if errs := params.Validate(); len(errs) > 0 {
+		return &ValidationError{Name: params.Name, Errors: errs}
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.nets[params.Name]; ok {
+		return ErrDuplicateNet
+	}
+	for _, registered := range r.order {
+		if registered.Net == params.Net && !isSharedNetMagic(registered, params) {
+			return ErrDuplicateNet
+		}
+	}
+
+	r.nets[params.Name] = params
+	r.order = append(r.order, params)
+
+	return nil
+}
+
+// Deregister removes a previously registered network from the registry. It is
+// meant for tests that register ephemeral networks.
+func (r *Registry) Deregister(params *Params) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	registered, ok := r.nets[params.Name]
+	if !ok || registered != params {
+		return ErrUnknownNet
+	}
+
+	delete(r.nets, params.Name)
+	for i, p := range r.order {
+		if p == params {
+			r.order = append(r.order[:i:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// isSharedNetMagic returns whether two networks are allowed to share their
+// network magic. Only chipnet, which reuses the magic of testnet4, is.
+func isSharedNetMagic(a, b *Params) bool {
+	return a == &Testnet4Params && b == &ChipnetParams || a == &ChipnetParams && b == &Testnet4Params
+}
+
+func (r *Registry) mustRegister(params *Params) {
+	err := r.Register(params)
+	if err != nil {
+		panic("failed to register network: " + err.Error())
+	}
+}
+
+// Networks returns every registered network in registration order.
+func (r *Registry) Networks() []*Params {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return append([]*Params(nil), r.order...)
+}
+
+// ParamsByName returns the network registered under the given name.
+func (r *Registry) ParamsByName(name string) (*Params, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	params, ok := r.nets[name]
+	if !ok {
+		return nil, ErrUnknownNet
+	}
+
+	return params, nil
+}
+
+// ParamsByNet returns the networks registered with the given network magic.
+func (r *Registry) ParamsByNet(net BitcoinNet) ([]*Params, error) {
+	return r.lookup(func(p *Params) bool { return p.Net == net })
+}
+
+// ParamsByCashAddressPrefix returns the networks registered with the given
+// cash address prefix. The prefix is case-insensitive and may be given with or
+// without its trailing colon.
+func (r *Registry) ParamsByCashAddressPrefix(prefix string) ([]*Params, error) {
+	prefix = normalizeCashAddressPrefix(prefix)
+
+	return r.lookup(func(p *Params) bool {
+		return normalizeCashAddressPrefix(p.CashAddressPrefix) == prefix
+	})
+}
+
+// ParamsByP2PKHAddrID returns the networks registered with the given legacy
+// pay-to-pubkey-hash address byte.
+func (r *Registry) ParamsByP2PKHAddrID(id byte) ([]*Params, error) {
+	return r.lookup(func(p *Params) bool { return p.LegacyP2PKHAddrID == id })
+}
+
+// ParamsByP2SHAddrID returns the networks registered with the given legacy
+// pay-to-script-hash address byte.
+func (r *Registry) ParamsByP2SHAddrID(id byte) ([]*Params, error) {
+	return r.lookup(func(p *Params) bool { return p.LegacyP2SHAddrID == id })
+}
+
+// ParamsByHDKeyID returns the networks registered with the given HD extended
+// key version, private or public.
+func (r *Registry) ParamsByHDKeyID(id [4]byte) ([]*Params, error) {
+	return r.lookup(func(p *Params) bool {
+		return p.HDPrivateKeyID == id || p.HDPublicKeyID == id
+	})
+}
+
+// IsP2PKHAddrID ...
+func (r *Registry) IsP2PKHAddrID(id byte) bool {
+	_, err := r.ParamsByP2PKHAddrID(id)
+	return err == nil
+}
+
+// IsP2SHAddrID ...
+func (r *Registry) IsP2SHAddrID(id byte) bool {
+	_, err := r.ParamsByP2SHAddrID(id)
+	return err == nil
+}
+
+// IsCashAddressPrefix ...
+func (r *Registry) IsCashAddressPrefix(prefix string) bool {
+	_, err := r.ParamsByCashAddressPrefix(prefix)
+	return err == nil
+}
+
+// HDPrivatekeyToPublickeyID ...
+func (r *Registry) HDPrivatekeyToPublickeyID(id []byte) ([]byte, error) {
+	if len(id) != 4 {
+		return nil, ErrUnknownHDKeyID
+	}
+
+	var key [4]byte
+	copy(key[:], id)
+
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	for _, params := range r.order {
+		if params.HDPrivateKeyID == key {
+			pubBytes := params.HDPublicKeyID
+			return pubBytes[:], nil
+		}
+	}
+
+	return nil, ErrUnknownHDKeyID
+}
+
+func (r *Registry) lookup(match func(*Params) bool) ([]*Params, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	var nets []*Params
+	for _, params := range r.order {
+		if match(params) {
+			nets = append(nets, params)
+		}
+	}
+
+	if len(nets) == 0 {
+		return nil, ErrUnknownNet
+	}
+
+	return nets, nil
+}
+
+// Register adds a network to the default registry.
+func Register(params *Params) error {
+	return defaultRegistry.Register(params)
+}
+
+// Deregister removes a network from the default registry.
+func Deregister(params *Params) error {
+	return defaultRegistry.Deregister(params)
+}
+
+// ParamsByName looks a network up by name in the default registry.
+func ParamsByName(name string) (*Params, error) {
+	return defaultRegistry.ParamsByName(name)
+}
+
+// ParamsByNet looks networks up by magic in the default registry.
+func ParamsByNet(net BitcoinNet) ([]*Params, error) {
+	return defaultRegistry.ParamsByNet(net)
+}
+
+// ParamsByCashAddressPrefix looks networks up by cash address prefix in the
+// default registry.
+func ParamsByCashAddressPrefix(prefix string) ([]*Params, error) {
+	return defaultRegistry.ParamsByCashAddressPrefix(prefix)
+}
+
+// ParamsByP2PKHAddrID looks networks up by legacy P2PKH address byte in the
+// default registry.
+func ParamsByP2PKHAddrID(id byte) ([]*Params, error) {
+	return defaultRegistry.ParamsByP2PKHAddrID(id)
+}
+
+// ParamsByP2SHAddrID looks networks up by legacy P2SH address byte in the
+// default registry.
+func ParamsByP2SHAddrID(id byte) ([]*Params, error) {
+	return defaultRegistry.ParamsByP2SHAddrID(id)
+}
+
+// ParamsByHDKeyID looks networks up by HD extended key version in the default
+// registry.
+func ParamsByHDKeyID(id [4]byte) ([]*Params, error) {
+	return defaultRegistry.ParamsByHDKeyID(id)
+}
+
+// IsP2PKHAddrID ...
+func IsP2PKHAddrID(id byte) bool {
+	return defaultRegistry.IsP2PKHAddrID(id)
+}
+
+// IsP2SHAddrID ...
+func IsP2SHAddrID(id byte) bool {
+	return defaultRegistry.IsP2SHAddrID(id)
+}
+
+// IsCashAddressPrefix ...
+func IsCashAddressPrefix(prefix string) bool {
+	return defaultRegistry.IsCashAddressPrefix(prefix)
+}
+
+// HDPrivatekeyToPublickeyID ...
+func HDPrivatekeyToPublickeyID(id []byte) ([]byte, error) {
+	return defaultRegistry.HDPrivatekeyToPublickeyID(id)
+}
+
+func normalizeCashAddressPrefix(prefix string) string {
+	return strings.TrimSuffix(strings.ToLower(prefix), ":")
+}
@@ -0,0 +1,270 @@
+package bchcfg
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestRegistryLookups verifies that every default network can be found by each
+// of its registry keys.
+func TestRegistryLookups(t *testing.T) {
+	contains := func(nets []*Params, params *Params) bool {
+		for _, p := range nets {
+			if p == params {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, params := range defaultNets {
+		got, err := ParamsByName(params.Name)
+		if err != nil || got != params {
+			t.Errorf("ParamsByName(%s) = %v, %v", params.Name, got, err)
+		}
+
+		nets, err := ParamsByNet(params.Net)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByNet(%s) = %v, %v", params.Name, nets, err)
+		}
+
+		nets, err = ParamsByCashAddressPrefix(params.CashAddressPrefix)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByCashAddressPrefix(%s) = %v, %v", params.Name, nets, err)
+		}
+
+		nets, err = ParamsByP2PKHAddrID(params.LegacyP2PKHAddrID)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByP2PKHAddrID(%s) = %v, %v", params.Name, nets, err)
+		}
+
+		nets, err = ParamsByP2SHAddrID(params.LegacyP2SHAddrID)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByP2SHAddrID(%s) = %v, %v", params.Name, nets, err)
+		}
+
+		nets, err = ParamsByHDKeyID(params.HDPrivateKeyID)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByHDKeyID(%s private) = %v, %v", params.Name, nets, err)
+		}
+
+		nets, err = ParamsByHDKeyID(params.HDPublicKeyID)
+		if err != nil || !contains(nets, params) {
+			t.Errorf("ParamsByHDKeyID(%s public) = %v, %v", params.Name, nets, err)
+		}
+
+		pubID, err := HDPrivatekeyToPublickeyID(params.HDPrivateKeyID[:])
+		if err != nil || string(pubID) != string(params.HDPublicKeyID[:]) {
+			t.Errorf("HDPrivatekeyToPublickeyID(%s) = %x, %v", params.Name, pubID, err)
+		}
+	}
+}
+
+// TestRegistryCashAddressPrefix verifies that prefix lookups ignore case and the
+// trailing colon.
+func TestRegistryCashAddressPrefix(t *testing.T) {
+	tests := []string{"bitcoincash", "bitcoincash:", "BITCOINCASH", "BitcoinCash:"}
+
+	for _, prefix := range tests {
+		nets, err := ParamsByCashAddressPrefix(prefix)
+		if err != nil || len(nets) != 1 || nets[0] != &MainnetParams {
+			t.Errorf("ParamsByCashAddressPrefix(%q) = %v, %v", prefix, nets, err)
+		}
+	}
+
+	if _, err := ParamsByCashAddressPrefix("bitcoincash::"); err != ErrUnknownNet {
+		t.Errorf("ParamsByCashAddressPrefix(bitcoincash::) = err %v (want: %v)", err, ErrUnknownNet)
+	}
+}
+
+// TestRegistryUnknown verifies that lookups for unregistered keys fail.
+func TestRegistryUnknown(t *testing.T) {
+	if _, err := ParamsByName("nonexistent"); err != ErrUnknownNet {
+		t.Errorf("ParamsByName = err %v (want: %v)", err, ErrUnknownNet)
+	}
+	if _, err := ParamsByNet(BitcoinNet(0xffffffff)); err != ErrUnknownNet {
+		t.Errorf("ParamsByNet = err %v (want: %v)", err, ErrUnknownNet)
+	}
+	if _, err := ParamsByHDKeyID([4]byte{0xff, 0xff, 0xff, 0xff}); err != ErrUnknownNet {
+		t.Errorf("ParamsByHDKeyID = err %v (want: %v)", err, ErrUnknownNet)
+	}
+	if _, err := HDPrivatekeyToPublickeyID([]byte{0xff}); err != ErrUnknownHDKeyID {
+		t.Errorf("HDPrivatekeyToPublickeyID = err %v (want: %v)", err, ErrUnknownHDKeyID)
+	}
+	if err := Deregister(&Params{Name: "nonexistent"}); err != ErrUnknownNet {
+		t.Errorf("Deregister = err %v (want: %v)", err, ErrUnknownNet)
+	}
+}
+
+// TestRegistryDuplicates verifies that a network reusing the name or the network
+// magic of a registered one is rejected, chipnet and testnet4 excepted.
+func TestRegistryDuplicates(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(&Testnet4Params); err != nil {
+		t.Fatalf("Register(testnet4): unexpected error %v", err)
+	}
+	if err := registry.Register(&ChipnetParams); err != nil {
+		t.Fatalf("Register(chipnet): unexpected error %v", err)
+	}
+
+	nets, err := registry.ParamsByNet(Testnet4)
+	if err != nil || len(nets) != 2 || nets[0] != &Testnet4Params || nets[1] != &ChipnetParams {
+		t.Errorf("ParamsByNet(Testnet4) = %v, %v (want: testnet4, chipnet)", nets, err)
+	}
+
+	sameMagic := ScalenetParams
+	sameMagic.Net = Testnet4
+	if err := registry.Register(&sameMagic); err != ErrDuplicateNet {
+		t.Errorf("Register(scalenet with testnet4 magic) = err %v (want: %v)", err, ErrDuplicateNet)
+	}
+
+	chipnetCopy := ChipnetParams
+	chipnetCopy.Name = "chipnetcopy"
+	if err := registry.Register(&chipnetCopy); err != ErrDuplicateNet {
+		t.Errorf("Register(copy of chipnet) = err %v (want: %v)", err, ErrDuplicateNet)
+	}
+
+	sameName := ScalenetParams
+	sameName.Name = ChipnetParams.Name
+	if err := registry.Register(&sameName); err != ErrDuplicateNet {
+		t.Errorf("Register(scalenet named chipnet) = err %v (want: %v)", err, ErrDuplicateNet)
+	}
+
+	if nets := registry.Networks(); len(nets) != 2 {
+		t.Errorf("Networks() = %d networks (want: 2)", len(nets))
+	}
+}
+
+// TestRegistryDeregister verifies that an ephemeral network can be registered
+// and removed again without disturbing the networks sharing its keys.
+func TestRegistryDeregister(t *testing.T) {
+	custom := RegTestnetParams
+	custom.Name = "customregtest"
+	custom.Net = BitcoinNet(0x0b110907)
+	custom.CashAddressPrefix = "bchcustom"
+	custom.HDPrivateKeyID = [4]byte{0x01, 0x02, 0x03, 0x04}
+	custom.HDPublicKeyID = [4]byte{0x05, 0x06, 0x07, 0x08}
+
+	if err := Register(&custom); err != nil {
+		t.Fatalf("Register: unexpected error %v", err)
+	}
+
+	nets, err := ParamsByP2PKHAddrID(custom.LegacyP2PKHAddrID)
+	if err != nil || nets[len(nets)-1] != &custom {
+		t.Errorf("ParamsByP2PKHAddrID = %v, %v (want custom network last)", nets, err)
+	}
+
+	// A copy sharing the name is not the registered network.
+	impostor := custom
+	if err := Deregister(&impostor); err != ErrUnknownNet {
+		t.Errorf("Deregister(impostor) = err %v (want: %v)", err, ErrUnknownNet)
+	}
+
+	if err := Deregister(&custom); err != nil {
+		t.Fatalf("Deregister: unexpected error %v", err)
+	}
+
+	if _, err := ParamsByName(custom.Name); err != ErrUnknownNet {
+		t.Errorf("ParamsByName after Deregister = err %v (want: %v)", err, ErrUnknownNet)
+	}
+	if IsCashAddressPrefix(custom.CashAddressPrefix) {
+		t.Errorf("IsCashAddressPrefix after Deregister = true (want: false)")
+	}
+	if _, err := HDPrivatekeyToPublickeyID(custom.HDPrivateKeyID[:]); err != ErrUnknownHDKeyID {
+		t.Errorf("HDPrivatekeyToPublickeyID after Deregister = err %v (want: %v)", err, ErrUnknownHDKeyID)
+	}
+	if !IsP2PKHAddrID(custom.LegacyP2PKHAddrID) {
+		t.Errorf("IsP2PKHAddrID after Deregister = false (want: true, shared with %s)", RegTestnetParams.Name)
+	}
+}
+
+// TestRegistryConcurrent exercises the registry from several goroutines and is
+// meant to be run with the race detector.
+func TestRegistryConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			params := SimnetParams
+			params.Name = fmt.Sprintf("concurrent%d", i)
+			params.Net = BitcoinNet(0xc0000000 + i)
+
+			for j := 0; j < 50; j++ {
+				if err := Register(&params); err != nil {
+					t.Errorf("Register(%s): unexpected error %v", params.Name, err)
+					return
+				}
+				if _, err := ParamsByNet(params.Net); err != nil {
+					t.Errorf("ParamsByNet(%s): unexpected error %v", params.Name, err)
+				}
+				if !IsP2SHAddrID(params.LegacyP2SHAddrID) {
+					t.Errorf("IsP2SHAddrID(%s) = false (want: true)", params.Name)
+				}
+				if err := Deregister(&params); err != nil {
+					t.Errorf("Deregister(%s): unexpected error %v", params.Name, err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// TestRegistryIsolation verifies that registries constructed explicitly do not
+// share state with each other or with the default registry.
+func TestRegistryIsolation(t *testing.T) {
+	empty := NewRegistry()
+	if nets := empty.Networks(); len(nets) != 0 {
+		t.Errorf("NewRegistry().Networks() = %d networks (want: 0)", len(nets))
+	}
+	if empty.IsP2PKHAddrID(MainnetParams.LegacyP2PKHAddrID) {
+		t.Errorf("NewRegistry().IsP2PKHAddrID(mainnet) = true (want: false)")
+	}
+
+	defaults := NewDefaultRegistry()
+	if nets := defaults.Networks(); len(nets) != len(defaultNets) {
+		t.Fatalf("NewDefaultRegistry().Networks() = %d networks (want: %d)", len(nets), len(defaultNets))
+	}
+	for i, params := range defaults.Networks() {
+		if params != defaultNets[i] {
+			t.Errorf("NewDefaultRegistry().Networks()[%d] = %s (want: %s)", i, params.Name, defaultNets[i].Name)
+		}
+	}
+
+	if err := defaults.Deregister(&MainnetParams); err != nil {
+		t.Fatalf("Deregister(mainnet): unexpected error %v", err)
+	}
+	if _, err := ParamsByName(MainnetParams.Name); err != nil {
+		t.Errorf("default registry lost mainnet: %v", err)
+	}
+
+	// Two regtest variants sharing a name and address bytes, each in its own
+	// registry.
+	a, b := RegTestnetParams, RegTestnetParams
+	a.Name, b.Name = "variant", "variant"
+	a.CashAddressPrefix, b.CashAddressPrefix = "varianta", "variantb"
+
+	ra, rb := NewRegistry(), NewRegistry()
+	if err := ra.Register(&a); err != nil {
+		t.Fatalf("Register(a): unexpected error %v", err)
+	}
+	if err := rb.Register(&b); err != nil {
+		t.Fatalf("Register(b): unexpected error %v", err)
+	}
+
+	nets, err := ra.ParamsByP2PKHAddrID(a.LegacyP2PKHAddrID)
+	if err != nil || len(nets) != 1 || nets[0] != &a {
+		t.Errorf("ra.ParamsByP2PKHAddrID = %v, %v (want: only a)", nets, err)
+	}
+	if ra.IsCashAddressPrefix(b.CashAddressPrefix) {
+		t.Errorf("ra.IsCashAddressPrefix(variantb) = true (want: false)")
+	}
+	if IsCashAddressPrefix(a.CashAddressPrefix) {
+		t.Errorf("IsCashAddressPrefix(varianta) = true in default registry (want: false)")
+	}
+}
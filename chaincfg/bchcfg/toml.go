@@ -0,0 +1,386 @@
+package bchcfg
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// This file implements the small subset of TOML needed to describe a network:
+// bare keys, single-line basic and literal strings, integers, booleans, tables
+// and arrays of tables, none of them nested. Other valid TOML, such as inline
+// arrays and tables, dotted or quoted keys, multi-line strings, floats and
+// dates, is rejected with an error naming the unsupported feature. Decoded
+// documents are handed over to encoding/json so that both formats share the
+// same struct tags.
+
+// decodeTOML parses a TOML document into a value suitable for json.Marshal.
+// Integers are kept as json.Number so that uint64 values survive the trip.
+func decodeTOML(r io.Reader) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(stripTOMLComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "[["):
+			if !strings.HasSuffix(line, "]]") {
+				return nil, tomlError(lineNum, "malformed array of tables header")
+			}
+			name := strings.TrimSpace(line[2 : len(line)-2])
+			if err := checkTOMLKey(name); err != nil {
+				return nil, tomlError(lineNum, "table name: %v", err)
+			}
+			if !isTOMLBareKey(name) {
+				return nil, tomlError(lineNum, "invalid table name %q", name)
+			}
+
+			var tables []interface{}
+			if existing, ok := root[name]; ok {
+				if tables, ok = existing.([]interface{}); !ok {
+					return nil, tomlError(lineNum, "%q is not an array of tables", name)
+				}
+			}
+
+			current = make(map[string]interface{})
+			root[name] = append(tables, current)
+
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, tomlError(lineNum, "malformed table header")
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if err := checkTOMLKey(name); err != nil {
+				return nil, tomlError(lineNum, "table name: %v", err)
+			}
+			if !isTOMLBareKey(name) {
+				return nil, tomlError(lineNum, "invalid table name %q", name)
+			}
+			if _, ok := root[name]; ok {
+				return nil, tomlError(lineNum, "duplicate table %q", name)
+			}
+
+			current = make(map[string]interface{})
+			root[name] = current
+
+		default:
+			eq := strings.IndexByte(line, '=')
+			if eq < 0 {
+				return nil, tomlError(lineNum, "expected key = value")
+			}
+
+			key := strings.TrimSpace(line[:eq])
+			if err := checkTOMLKey(key); err != nil {
+				return nil, tomlError(lineNum, "%v", err)
+			}
+			if !isTOMLBareKey(key) {
+				return nil, tomlError(lineNum, "invalid key %q", key)
+			}
+			if _, ok := current[key]; ok {
+				return nil, tomlError(lineNum, "duplicate key %q", key)
+			}
+
+			value, err := parseTOMLValue(strings.TrimSpace(line[eq+1:]))
+			if err != nil {
+				return nil, tomlError(lineNum, "key %q: %v", key, err)
+			}
+			current[key] = value
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+func tomlError(lineNum int, format string, args ...interface{}) error {
+	return fmt.Errorf("toml: line %d: %s", lineNum, fmt.Sprintf(format, args...))
+}
+
+// stripTOMLComment removes a trailing comment, ignoring '#' inside strings.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// errTOMLUnsupported returns the error reported for valid TOML outside of the
+// supported subset.
+func errTOMLUnsupported(feature string) error {
+	return fmt.Errorf("%s are not supported", feature)
+}
+
+// checkTOMLKey rejects the valid key syntaxes beyond bare keys.
+func checkTOMLKey(key string) error {
+	switch {
+	case strings.HasPrefix(key, "\"") || strings.HasPrefix(key, "'"):
+		return errTOMLUnsupported("quoted keys")
+	case strings.Contains(key, "."):
+		return errTOMLUnsupported("dotted keys")
+	}
+
+	return nil
+}
+
+func isTOMLBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseTOMLValue(s string) (interface{}, error) {
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("missing value")
+
+	case s == "true":
+		return true, nil
+
+	case s == "false":
+		return false, nil
+
+	case strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''"):
+		return nil, errTOMLUnsupported("multi-line strings")
+
+	case s[0] == '[':
+		return nil, errTOMLUnsupported("inline arrays")
+
+	case s[0] == '{':
+		return nil, errTOMLUnsupported("inline tables")
+
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' || strings.IndexByte(s[1:len(s)-1], '\'') >= 0 {
+			return nil, fmt.Errorf("malformed literal string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+
+	case s[0] == '"':
+		return parseTOMLBasicString(s)
+	}
+
+	digits := strings.Replace(s, "_", "", -1)
+	if strings.HasPrefix(digits, "0x") {
+		n, err := strconv.ParseUint(digits[2:], 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %s", s)
+		}
+		return json.Number(strconv.FormatUint(n, 10)), nil
+	}
+
+	if _, err := strconv.ParseInt(digits, 10, 64); err == nil {
+		return json.Number(strings.TrimPrefix(digits, "+")), nil
+	}
+	if _, err := strconv.ParseUint(strings.TrimPrefix(digits, "+"), 10, 64); err == nil {
+		return json.Number(strings.TrimPrefix(digits, "+")), nil
+	}
+
+	if _, err := strconv.ParseFloat(digits, 64); err == nil {
+		return nil, errTOMLUnsupported("floats")
+	}
+	if strings.ContainsAny(s, ":") || strings.Count(s, "-") >= 2 && s[0] != '-' {
+		return nil, errTOMLUnsupported("dates and times")
+	}
+
+	return nil, fmt.Errorf("unsupported value %s", s)
+}
+
+func parseTOMLBasicString(s string) (string, error) {
+	if len(s) < 2 || s[len(s)-1] != '"' {
+		return "", fmt.Errorf("malformed string %s", s)
+	}
+
+	var sb strings.Builder
+	body := s[1 : len(s)-1]
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		if c == '"' {
+			return "", fmt.Errorf("malformed string %s", s)
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i == len(body) {
+			return "", fmt.Errorf("malformed string %s", s)
+		}
+
+		switch body[i] {
+		case '"', '\\':
+			sb.WriteByte(body[i])
+		case 'b':
+			sb.WriteByte('\b')
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'u', 'U':
+			size := 4
+			if body[i] == 'U' {
+				size = 8
+			}
+			if i+1+size > len(body) {
+				return "", fmt.Errorf("malformed escape in string %s", s)
+			}
+			r, err := strconv.ParseUint(body[i+1:i+1+size], 16, 32)
+			if err != nil || !utf8.ValidRune(rune(r)) {
+				return "", fmt.Errorf("malformed escape in string %s", s)
+			}
+			sb.WriteRune(rune(r))
+			i += size
+		default:
+			return "", fmt.Errorf("malformed escape in string %s", s)
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// encodeTOML writes a struct as a TOML document, naming keys after the json
+// struct tags. Scalar fields come first, followed by struct fields as tables
+// and slices of structs as arrays of tables, in field order.
+func encodeTOML(w io.Writer, v interface{}) error {
+	bw := bufio.NewWriter(w)
+
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if err := writeTOMLScalars(bw, rv); err != nil {
+		return err
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, omitEmpty := tomlFieldName(rt.Field(i))
+		field := rv.Field(i)
+		if name == "" || omitEmpty && field.IsZero() {
+			continue
+		}
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			fmt.Fprintf(bw, "\n[%s]\n", name)
+			if err := writeTOMLScalars(bw, field); err != nil {
+				return err
+			}
+
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				fmt.Fprintf(bw, "\n[[%s]]\n", name)
+				if err := writeTOMLScalars(bw, field.Index(j)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func writeTOMLScalars(w io.Writer, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name, omitEmpty := tomlFieldName(rt.Field(i))
+		field := rv.Field(i)
+		if name == "" || omitEmpty && field.IsZero() {
+			continue
+		}
+
+		var value string
+		switch field.Kind() {
+		case reflect.String:
+			value = quoteTOMLString(field.String())
+		case reflect.Bool:
+			value = strconv.FormatBool(field.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value = strconv.FormatInt(field.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value = strconv.FormatUint(field.Uint(), 10)
+		case reflect.Struct, reflect.Slice:
+			continue
+		default:
+			return fmt.Errorf("toml: unsupported field %s of kind %s", rt.Field(i).Name, field.Kind())
+		}
+
+		if _, err := fmt.Fprintf(w, "%s = %s\n", name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func tomlFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" || field.PkgPath != "" {
+		return "", false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, opts == "omitempty"
+}
+
+func quoteTOMLString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
@@ -0,0 +1,157 @@
+package bchcfg
+
+import (
+	"fmt"
+	"time"
+)
+
+// Upgrade identifies a network upgrade configured in Params.
+type Upgrade int
+
+const (
+	// UpgradeBIP0034 ...
+	UpgradeBIP0034 Upgrade = iota
+
+	// UpgradeBIP0065 ...
+	UpgradeBIP0065
+
+	// UpgradeBIP0066 ...
+	UpgradeBIP0066
+
+	// UpgradeUAHF ...
+	UpgradeUAHF
+
+	// UpgradeDAA ...
+	UpgradeDAA
+
+	// UpgradeMagneticAnomaly ...
+	UpgradeMagneticAnomaly
+
+	// UpgradeGreatWall ...
+	UpgradeGreatWall
+
+	// UpgradeGraviton ...
+	UpgradeGraviton
+
+	// UpgradePhonon ...
+	UpgradePhonon
+
+	// UpgradeAxion ...
+	UpgradeAxion
+
+	// Upgrade8 ...
+	Upgrade8
+
+	// Upgrade9 ...
+	Upgrade9
+
+	// Upgrade10 ...
+	Upgrade10
+
+	// Upgrade11 ...
+	Upgrade11
+
+	// DefinedUpgrades ...
+	DefinedUpgrades
+)
+
+var upgradeNames = [DefinedUpgrades]string{
+	UpgradeBIP0034:         "BIP0034",
+	UpgradeBIP0065:         "BIP0065",
+	UpgradeBIP0066:         "BIP0066",
+	UpgradeUAHF:            "UAHF",
+	UpgradeDAA:             "DAA",
+	UpgradeMagneticAnomaly: "MagneticAnomaly",
+	UpgradeGreatWall:       "GreatWall",
+	UpgradeGraviton:        "Graviton",
+	UpgradePhonon:          "Phonon",
+	UpgradeAxion:           "Axion",
+	Upgrade8:               "Upgrade8",
+	Upgrade9:               "Upgrade9",
+	Upgrade10:              "Upgrade10",
+	Upgrade11:              "Upgrade11",
+}
+
+// String ...
+func (u Upgrade) String() string {
+	if u < 0 || u >= DefinedUpgrades {
+		return fmt.Sprintf("Upgrade(%d)", int(u))
+	}
+
+	return upgradeNames[u]
+}
+
+// IsTimeBased returns whether the upgrade activates on the median time past
+// rather than on the block height.
+func (u Upgrade) IsTimeBased() bool {
+	switch u {
+	case UpgradeGreatWall, UpgradeGraviton, UpgradePhonon, UpgradeAxion,
+		Upgrade8, Upgrade9, Upgrade10, Upgrade11:
+		return true
+	}
+
+	return false
+}
+
+// IsUpgradeActive returns whether the rules of an upgrade apply to the block at
+// height, whose parent has the median time past mtp.
+//
+// An upgrade activates at the first block it applies to:
+//
+//   - BIP0034, BIP0065 and BIP0066 activate at the block whose height is
+//     BIP00xxHeight.
+//   - UAHF, DAA and Magnetic Anomaly activate at the block following the block
+//     whose height is the ForkHeight, so ForkHeight is the last block validated
+//     under the old rules.
+//   - Time based upgrades activate at the first block whose parent has a median
+//     time past at or after the ActivationTime. An activation time of zero
+//     means the upgrade is active from genesis.
+//
+// mtp is ignored for height based upgrades and height for time based ones.
+func (p *Params) IsUpgradeActive(u Upgrade, height int32, mtp time.Time) bool {
+	switch u {
+	case UpgradeBIP0034:
+		return height >= p.BIP0034Height
+	case UpgradeBIP0065:
+		return height >= p.BIP0065Height
+	case UpgradeBIP0066:
+		return height >= p.BIP0066Height
+	case UpgradeUAHF:
+		return height > p.UAHFForkHeight
+	case UpgradeDAA:
+		return height > p.DAAForkHeight
+	case UpgradeMagneticAnomaly:
+		return height > p.MagneticAnomalyForkHeight
+	case UpgradeGreatWall:
+		return isTimeReached(mtp, p.GreatWallActivationTime)
+	case UpgradeGraviton:
+		return isTimeReached(mtp, p.GravitonActivationTime)
+	case UpgradePhonon:
+		return isTimeReached(mtp, p.PhononActivationTime)
+	case UpgradeAxion:
+		return isTimeReached(mtp, p.AxionActivationTime)
+	case Upgrade8:
+		return isTimeReached(mtp, p.Upgrade8ActivationTime)
+	case Upgrade9:
+		return isTimeReached(mtp, p.Upgrade9ActivationTime)
+	case Upgrade10:
+		return isTimeReached(mtp, p.Upgrade10ActivationTime)
+	case Upgrade11:
+		return isTimeReached(mtp, p.Upgrade11ActivationTime)
+	}
+
+	return false
+}
+
+// isTimeReached returns whether mtp is at or after activationTime. A zero
+// activation time is reached whatever mtp, including the zero time.Time and
+// times before the epoch.
+func isTimeReached(mtp time.Time, activationTime uint64) bool {
+	if activationTime == 0 {
+		return true
+	}
+
+	t := mtp.Unix()
+
+	return t >= 0 && uint64(t) >= activationTime
+}
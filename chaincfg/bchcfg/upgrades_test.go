@@ -0,0 +1,86 @@
+package bchcfg
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIsUpgradeActive verifies the first block each mainnet upgrade applies to.
+// (Block #478,559 is the first Bitcoin Cash block, #556,767 the first Magnetic
+// Anomaly block)
+func TestIsUpgradeActive(t *testing.T) {
+	params := &MainnetParams
+
+	heightTests := []struct {
+		upgrade Upgrade
+		height  int32
+	}{
+		{UpgradeBIP0034, 227931},
+		{UpgradeBIP0065, 388381},
+		{UpgradeBIP0066, 363725},
+		{UpgradeUAHF, 478559},
+		{UpgradeDAA, 504032},
+		{UpgradeMagneticAnomaly, 556767},
+	}
+
+	for _, test := range heightTests {
+		if params.IsUpgradeActive(test.upgrade, test.height-1, time.Time{}) {
+			t.Errorf("IsUpgradeActive(%s, %d) = true (want: false)", test.upgrade, test.height-1)
+		}
+		if !params.IsUpgradeActive(test.upgrade, test.height, time.Time{}) {
+			t.Errorf("IsUpgradeActive(%s, %d) = false (want: true)", test.upgrade, test.height)
+		}
+		if test.upgrade.IsTimeBased() {
+			t.Errorf("%s.IsTimeBased() = true (want: false)", test.upgrade)
+		}
+	}
+
+	timeTests := []struct {
+		upgrade Upgrade
+		time    uint64
+	}{
+		{UpgradeGreatWall, params.GreatWallActivationTime},
+		{UpgradeGraviton, params.GravitonActivationTime},
+		{UpgradePhonon, params.PhononActivationTime},
+		{UpgradeAxion, params.AxionActivationTime},
+		{Upgrade8, params.Upgrade8ActivationTime},
+		{Upgrade9, params.Upgrade9ActivationTime},
+		{Upgrade10, params.Upgrade10ActivationTime},
+		{Upgrade11, params.Upgrade11ActivationTime},
+	}
+
+	for _, test := range timeTests {
+		activation := time.Unix(int64(test.time), 0)
+		if params.IsUpgradeActive(test.upgrade, 1<<30, activation.Add(-time.Second)) {
+			t.Errorf("IsUpgradeActive(%s) = true before activation (want: false)", test.upgrade)
+		}
+		if !params.IsUpgradeActive(test.upgrade, 0, activation) {
+			t.Errorf("IsUpgradeActive(%s) = false at activation (want: true)", test.upgrade)
+		}
+		if !test.upgrade.IsTimeBased() {
+			t.Errorf("%s.IsTimeBased() = false (want: true)", test.upgrade)
+		}
+		for _, mtp := range []time.Time{time.Unix(0, 0), {}, time.Unix(-1, 0)} {
+			if !RegTestnetParams.IsUpgradeActive(test.upgrade, 0, mtp) {
+				t.Errorf("IsUpgradeActive(%s, %v) = false on regtest (want: true)", test.upgrade, mtp)
+			}
+		}
+	}
+
+	if params.IsUpgradeActive(DefinedUpgrades, 1<<30, time.Now()) {
+		t.Error("IsUpgradeActive = true for an unknown upgrade")
+	}
+}
+
+// TestUpgradeString verifies upgrade names, including unknown upgrades.
+func TestUpgradeString(t *testing.T) {
+	for u := Upgrade(0); u < DefinedUpgrades; u++ {
+		if u.String() == "" {
+			t.Errorf("Upgrade(%d).String() is empty", int(u))
+		}
+	}
+
+	if s := Upgrade(-1).String(); s != "Upgrade(-1)" {
+		t.Errorf("String = %s (want: Upgrade(-1))", s)
+	}
+}
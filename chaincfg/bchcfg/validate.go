@@ -0,0 +1,161 @@
+package bchcfg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// ValidationError is returned by Register and the file decoders when Params
+// fails Validate. It holds every inconsistency that was found.
+type ValidationError struct {
+	Name   string
+	Errors []error
+}
+
+// Error ...
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("invalid parameters for network %q: %s", e.Name, strings.Join(msgs, "; "))
+}
+
+// Validate checks the parameters for internal consistency and returns one
+// descriptive error per problem found, or nil if there is none.
+func (p *Params) Validate() []error {
+	var errs []error
+	addErr := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if p.Name == "" {
+		addErr("name is empty")
+	}
+
+	switch {
+	case p.PowLimit == nil || p.PowLimit.Sign() <= 0:
+		addErr("pow limit is not positive")
+	case pow.BigToCompact(p.PowLimit) != p.PowLimitBits:
+		addErr("pow limit bits %08x do not match pow limit (compact form %08x)",
+			p.PowLimitBits, pow.BigToCompact(p.PowLimit))
+	}
+
+	if p.TargetTimePerBlock <= 0 {
+		addErr("target time per block %v is not positive", p.TargetTimePerBlock)
+	}
+
+	// A zero half life disables ASERT. Otherwise the half life is used in
+	// whole seconds as a divisor, and the anchor block must hold a target the
+	// network can reach.
+	if p.ASERTHalfLife != 0 {
+		if p.ASERTHalfLife < time.Second {
+			addErr("ASERT half life %v is shorter than one second", p.ASERTHalfLife)
+		}
+		if p.ASERTAnchorHeight < p.DAAForkHeight {
+			addErr("ASERT anchor height %d is before DAA fork height %d",
+				p.ASERTAnchorHeight, p.DAAForkHeight)
+		}
+		if p.ASERTAnchorParentTime < 0 {
+			addErr("ASERT anchor parent time %d is negative", p.ASERTAnchorParentTime)
+		}
+
+		// A missing pow limit is reported above.
+		anchorTarget := pow.CompactToBig(p.ASERTAnchorBits)
+		aboveLimit := p.PowLimit != nil && p.PowLimit.Sign() > 0 && anchorTarget.Cmp(p.PowLimit) > 0
+		if anchorTarget.Sign() <= 0 || aboveLimit {
+			addErr("ASERT anchor bits %08x are not a target within the pow limit", p.ASERTAnchorBits)
+		}
+	}
+
+	// The BIP0034, BIP0065 and BIP0066 heights follow no order, neither among
+	// themselves nor with the forks: regtest defers BIP0034 past every fork
+	// and the newer test networks activate BIP0065 before BIP0066. They are
+	// only checked for being non-negative.
+	heights := []struct {
+		upgrade Upgrade
+		height  int32
+	}{
+		{UpgradeBIP0034, p.BIP0034Height},
+		{UpgradeBIP0065, p.BIP0065Height},
+		{UpgradeBIP0066, p.BIP0066Height},
+		{UpgradeUAHF, p.UAHFForkHeight},
+		{UpgradeDAA, p.DAAForkHeight},
+		{UpgradeMagneticAnomaly, p.MagneticAnomalyForkHeight},
+	}
+	for _, h := range heights {
+		if h.height < 0 {
+			addErr("%s height %d is negative", h.upgrade, h.height)
+		}
+	}
+
+	if p.UAHFForkHeight > p.DAAForkHeight {
+		addErr("UAHF fork height %d is after DAA fork height %d",
+			p.UAHFForkHeight, p.DAAForkHeight)
+	}
+	if p.DAAForkHeight > p.MagneticAnomalyForkHeight {
+		addErr("DAA fork height %d is after Magnetic Anomaly fork height %d",
+			p.DAAForkHeight, p.MagneticAnomalyForkHeight)
+	}
+
+	// Time based upgrades are defined in activation order, a later upgrade
+	// cannot activate before an earlier one.
+	times := []struct {
+		upgrade Upgrade
+		time    uint64
+	}{
+		{UpgradeGreatWall, p.GreatWallActivationTime},
+		{UpgradeGraviton, p.GravitonActivationTime},
+		{UpgradePhonon, p.PhononActivationTime},
+		{UpgradeAxion, p.AxionActivationTime},
+		{Upgrade8, p.Upgrade8ActivationTime},
+		{Upgrade9, p.Upgrade9ActivationTime},
+		{Upgrade10, p.Upgrade10ActivationTime},
+		{Upgrade11, p.Upgrade11ActivationTime},
+	}
+	for i := 1; i < len(times); i++ {
+		prev, cur := times[i-1], times[i]
+		if cur.time < prev.time {
+			addErr("%s activation time %d is before %s activation time %d",
+				cur.upgrade, cur.time, prev.upgrade, prev.time)
+		}
+	}
+
+	if err := ValidateCheckpoints(p.Checkpoints); err != nil {
+		errs = append(errs, err)
+	}
+
+	if p.MinerConfirmationWindow == 0 {
+		addErr("miner confirmation window is zero")
+	}
+	if p.RuleChangeActivationThreshold > p.MinerConfirmationWindow {
+		addErr("rule change activation threshold %d is greater than miner confirmation window %d",
+			p.RuleChangeActivationThreshold, p.MinerConfirmationWindow)
+	}
+
+	for i, deployment := range p.Deployments {
+		// Bits 29 to 31 are the BIP9 top bits of the block version.
+		if deployment.BitNumber > 28 {
+			addErr("deployment %d uses bit %d outside of [0, 28]", i, deployment.BitNumber)
+		}
+		for j := 0; j < i; j++ {
+			if p.Deployments[j].BitNumber == deployment.BitNumber {
+				addErr("deployments %d and %d both use bit %d", j, i, deployment.BitNumber)
+			}
+		}
+		if deployment.StartTime > deployment.ExpireTime {
+			addErr("deployment %d starts at %d after it expires at %d",
+				i, deployment.StartTime, deployment.ExpireTime)
+		}
+	}
+
+	if err := p.ABLAConfig.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
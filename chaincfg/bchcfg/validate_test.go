@@ -0,0 +1,183 @@
+package bchcfg
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestValidateDefaultNets verifies that every default network is consistent.
+func TestValidateDefaultNets(t *testing.T) {
+	for _, params := range defaultNets {
+		if errs := params.Validate(); len(errs) != 0 {
+			t.Errorf("%s: Validate() = %v (want: no error)", params.Name, errs)
+		}
+	}
+}
+
+// TestValidate verifies that each kind of inconsistency is reported.
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *Params)
+		want   []string
+	}{
+		{
+			"pow limit bits mismatch",
+			func(p *Params) { p.PowLimitBits = 0x207fffff },
+			[]string{"pow limit bits 207fffff do not match pow limit (compact form 1d00ffff)"},
+		},
+		{
+			"missing pow limit",
+			func(p *Params) { p.PowLimit = nil },
+			[]string{"pow limit is not positive"},
+		},
+		{
+			"negative pow limit",
+			func(p *Params) { p.PowLimit = big.NewInt(-1) },
+			[]string{"pow limit is not positive"},
+		},
+		{
+			"colliding deployment bits",
+			func(p *Params) { p.Deployments[DeploymentCSV].BitNumber = p.Deployments[DeploymentTestDummy].BitNumber },
+			[]string{"deployments 0 and 1 both use bit 28"},
+		},
+		{
+			"deployment expiring before its start",
+			func(p *Params) { p.Deployments[DeploymentCSV].StartTime = p.Deployments[DeploymentCSV].ExpireTime + 1 },
+			[]string{"deployment 1 starts at"},
+		},
+		{
+			"checkpoints out of order",
+			func(p *Params) {
+				p.Checkpoints = []Checkpoint{
+					{11111, newHashFromStr("0000000069e244f73d78e8fd29ba2fd2ed618bd6fa2ee92559f542fdb26e7c1d")},
+					{11111, newHashFromStr("0000000069e244f73d78e8fd29ba2fd2ed618bd6fa2ee92559f542fdb26e7c1d")},
+				}
+			},
+			[]string{"checkpoint at height 11111 follows checkpoint at height 11111"},
+		},
+		{
+			"checkpoint without hash",
+			func(p *Params) { p.Checkpoints = []Checkpoint{{Height: 11111}} },
+			[]string{"checkpoint at height 11111 has no hash"},
+		},
+		{
+			"checkpoint at negative height",
+			func(p *Params) { p.Checkpoints = []Checkpoint{{-1, p.Checkpoints[0].Hash}} },
+			[]string{"checkpoint at negative height -1"},
+		},
+		{
+			"negative bip heights",
+			func(p *Params) { p.BIP0034Height, p.BIP0066Height = -1, -2 },
+			[]string{"BIP0034 height -1 is negative", "BIP0066 height -2 is negative"},
+		},
+		{
+			"fork heights going backwards",
+			func(p *Params) { p.UAHFForkHeight, p.DAAForkHeight, p.MagneticAnomalyForkHeight = 100, 50, 10 },
+			[]string{
+				"UAHF fork height 100 is after DAA fork height 50",
+				"DAA fork height 50 is after Magnetic Anomaly fork height 10",
+			},
+		},
+		{
+			"activation times going backwards",
+			func(p *Params) { p.Upgrade9ActivationTime = 1 },
+			[]string{"Upgrade9 activation time 1 is before Upgrade8 activation time 1652616000"},
+		},
+		{
+			"threshold above window",
+			func(p *Params) { p.RuleChangeActivationThreshold = p.MinerConfirmationWindow + 1 },
+			[]string{"rule change activation threshold 2017 is greater than miner confirmation window 2016"},
+		},
+		{
+			"zero window",
+			func(p *Params) { p.MinerConfirmationWindow, p.RuleChangeActivationThreshold = 0, 0 },
+			[]string{"miner confirmation window is zero"},
+		},
+		{
+			"deployment bit in the top bits",
+			func(p *Params) { p.Deployments[DeploymentCSV].BitNumber = 29 },
+			[]string{"deployment 1 uses bit 29 outside of [0, 28]"},
+		},
+		{
+			"sub-second asert half life",
+			func(p *Params) { p.ASERTHalfLife = time.Millisecond },
+			[]string{"ASERT half life 1ms is shorter than one second"},
+		},
+		{
+			"negative asert half life",
+			func(p *Params) { p.ASERTHalfLife = -time.Hour },
+			[]string{"ASERT half life -1h0m0s is shorter than one second"},
+		},
+		{
+			"bad asert anchor",
+			func(p *Params) {
+				p.ASERTAnchorHeight, p.ASERTAnchorParentTime, p.ASERTAnchorBits = 2999, -1, 0x207fffff
+			},
+			[]string{
+				"ASERT anchor height 2999 is before DAA fork height 3000",
+				"ASERT anchor parent time -1 is negative",
+				"ASERT anchor bits 207fffff are not a target within the pow limit",
+			},
+		},
+		{
+			"zero asert anchor bits",
+			func(p *Params) { p.ASERTAnchorBits = 0 },
+			[]string{"ASERT anchor bits 00000000 are not a target within the pow limit"},
+		},
+		{
+			"asert disabled",
+			func(p *Params) { p.ASERTHalfLife, p.ASERTAnchorHeight, p.ASERTAnchorBits = 0, 0, 0 },
+			nil,
+		},
+		{
+			"invalid abla config",
+			func(p *Params) { p.ABLAConfig.Delta = 33 },
+			[]string{"abla: delta out of range [0, 32]"},
+		},
+		{
+			"several problems",
+			func(p *Params) { p.Name, p.TargetTimePerBlock = "", 0 },
+			[]string{"name is empty", "target time per block 0s is not positive"},
+		},
+	}
+
+	for _, test := range tests {
+		params := Testnet4Params
+		test.modify(&params)
+
+		errs := params.Validate()
+		if len(errs) != len(test.want) {
+			t.Errorf("%s: Validate() = %v (want: %d errors)", test.name, errs, len(test.want))
+			continue
+		}
+
+		for i, err := range errs {
+			if !strings.HasPrefix(err.Error(), test.want[i]) {
+				t.Errorf("%s: error %d = %q (want: %q)", test.name, i, err, test.want[i])
+			}
+		}
+	}
+}
+
+// TestRegisterInvalid verifies that Register rejects inconsistent parameters.
+func TestRegisterInvalid(t *testing.T) {
+	params := RegTestnetParams
+	params.Name = "invalidregtest"
+	params.PowLimitBits = 0x1d00ffff
+	params.RuleChangeActivationThreshold = params.MinerConfirmationWindow + 1
+
+	err := NewRegistry().Register(&params)
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("Register = err %v (want: *ValidationError)", err)
+	}
+	if verr.Name != params.Name || len(verr.Errors) != 2 {
+		t.Errorf("Register = %v (want: 2 errors for %s)", verr, params.Name)
+	}
+	if !strings.Contains(err.Error(), `invalid parameters for network "invalidregtest"`) {
+		t.Errorf("Error() = %q", err)
+	}
+}
@@ -0,0 +1,43 @@
+package btccfg
+
+import (
+	"testing"
+)
+
+var defaultNets = []*Params{
+	&MainnetParams,
+	&RegtestParams,
+	&Testnet3Params,
+	&Testnet4Params,
+	&SignetParams,
+}
+
+// TestNetworkConsistency verifies that no two networks share a name, a magic or
+// a port, and that the deployments and checkpoints of each are well formed.
+func TestNetworkConsistency(t *testing.T) {
+	for i, a := range defaultNets {
+		for _, b := range defaultNets[i+1:] {
+			if a.Name == b.Name || a.Net == b.Net || a.DefaultPort == b.DefaultPort {
+				t.Errorf("%s and %s share their name, magic or port", a.Name, b.Name)
+			}
+		}
+
+		bits := make(map[uint8]bool)
+		for _, deployment := range a.Deployments {
+			if bits[deployment.BitNumber] {
+				t.Errorf("%s: deployment bit %d used twice", a.Name, deployment.BitNumber)
+			}
+			bits[deployment.BitNumber] = true
+		}
+
+		for j := 1; j < len(a.Checkpoints); j++ {
+			if a.Checkpoints[j].Height <= a.Checkpoints[j-1].Height {
+				t.Errorf("%s: checkpoint %d out of order", a.Name, j)
+			}
+		}
+
+		if a.RuleChangeActivationThreshold > a.MinerConfirmationWindow {
+			t.Errorf("%s: activation threshold above confirmation window", a.Name)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package chaincfg
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+// Chain names returned by Params.ChainName.
+const (
+	// ChainBTC ...
+	ChainBTC = "btc"
+
+	// ChainBCH ...
+	ChainBCH = "bch"
+
+	// ChainXEC ...
+	ChainXEC = "xec"
+)
+
+// Params is implemented by the network parameters of every chain package
+// (btccfg, bchcfg and xeccfg) so that address, HD and difficulty code can be
+// written once for all of them. The method names differ from the field names
+// of the concrete Params types, which stay the primary API of each package.
+type Params interface {
+	// ChainName returns the chain the network belongs to, one of the Chain
+	// constants.
+	ChainName() string
+
+	// NetName returns the name of the network within its chain, such as
+	// "mainnet" or "testnet3".
+	NetName() string
+
+	// NetMagic returns the magic bytes of the network messages.
+	NetMagic() uint32
+
+	// P2PPort returns the default peer-to-peer port.
+	P2PPort() string
+
+	// GenesisBlockHash returns the hash of the genesis block, or nil when it
+	// is unknown.
+	GenesisBlockHash() *chainhash.Hash
+
+	// ProofOfWorkLimit returns the highest allowed proof-of-work target and
+	// ProofOfWorkLimitBits its compact form.
+	ProofOfWorkLimit() *big.Int
+	ProofOfWorkLimitBits() uint32
+
+	// ProofOfWorkHasher returns the hash function block headers are checked
+	// against the target with.
+	ProofOfWorkHasher() pow.Hasher
+
+	// TargetBlockSpacing returns the desired time between blocks.
+	TargetBlockSpacing() time.Duration
+
+	// P2PKHAddrID, P2SHAddrID and WIFKeyID return the version bytes of base58
+	// pay-to-pubkey-hash and pay-to-script-hash addresses and of WIF private
+	// keys.
+	P2PKHAddrID() byte
+	P2SHAddrID() byte
+	WIFKeyID() byte
+
+	// HDPrivateKeyVersion and HDPublicKeyVersion return the version bytes of
+	// BIP32 extended keys, and HDCoinTypeIndex the BIP44 coin type.
+	HDPrivateKeyVersion() [4]byte
+	HDPublicKeyVersion() [4]byte
+	HDCoinTypeIndex() uint32
+
+	// CashAddrPrefix returns the cash address prefix, without its colon, or
+	// the empty string on chains without cash addresses.
+	CashAddrPrefix() string
+
+	// SegwitHRP returns the human-readable part of segwit addresses, or the
+	// empty string on chains without segwit.
+	SegwitHRP() string
+}
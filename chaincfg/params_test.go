@@ -0,0 +1,85 @@
+package chaincfg_test
+
+import (
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/btccfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/xeccfg"
+	"github.com/checksum0/go-cryptoutils/pow"
+)
+
+var allNets = []chaincfg.Params{
+	&btccfg.MainnetParams,
+	&btccfg.Testnet3Params,
+	&btccfg.Testnet4Params,
+	&btccfg.SignetParams,
+	&btccfg.RegtestParams,
+	&bchcfg.MainnetParams,
+	&bchcfg.Testnet3Params,
+	&bchcfg.RegTestnetParams,
+	&bchcfg.SimnetParams,
+	&bchcfg.Testnet4Params,
+	&bchcfg.ScalenetParams,
+	&bchcfg.ChipnetParams,
+	&xeccfg.MainnetParams,
+	&xeccfg.Testnet3Params,
+	&xeccfg.RegTestnetParams,
+}
+
+// TestParamsInterface verifies that the networks of every chain are
+// consistent when seen through the common interface.
+func TestParamsInterface(t *testing.T) {
+	type netID struct {
+		chain, name string
+	}
+	seen := make(map[netID]bool)
+
+	for _, params := range allNets {
+		id := netID{params.ChainName(), params.NetName()}
+		if seen[id] {
+			t.Errorf("%s/%s is defined twice", id.chain, id.name)
+		}
+		seen[id] = true
+
+		if bits := pow.BigToCompact(params.ProofOfWorkLimit()); bits != params.ProofOfWorkLimitBits() {
+			t.Errorf("%s/%s: pow limit compact form %08x (want: %08x)", id.chain, id.name, bits, params.ProofOfWorkLimitBits())
+		}
+		if params.TargetBlockSpacing() <= 0 {
+			t.Errorf("%s/%s: target block spacing %v is not positive", id.chain, id.name, params.TargetBlockSpacing())
+		}
+
+		switch params.ChainName() {
+		case chaincfg.ChainBTC:
+			if params.SegwitHRP() == "" || params.CashAddrPrefix() != "" {
+				t.Errorf("%s/%s: segwit %q, cashaddr %q", id.chain, id.name, params.SegwitHRP(), params.CashAddrPrefix())
+			}
+		case chaincfg.ChainBCH, chaincfg.ChainXEC:
+			if params.SegwitHRP() != "" || params.CashAddrPrefix() == "" {
+				t.Errorf("%s/%s: segwit %q, cashaddr %q", id.chain, id.name, params.SegwitHRP(), params.CashAddrPrefix())
+			}
+		default:
+			t.Errorf("%s/%s: unknown chain", id.chain, id.name)
+		}
+	}
+}
+
+// TestSharedGenesis verifies that the chains that forked from Bitcoin share its
+// genesis block.
+func TestSharedGenesis(t *testing.T) {
+	tests := []struct {
+		btc, fork chaincfg.Params
+	}{
+		{&btccfg.MainnetParams, &xeccfg.MainnetParams},
+		{&btccfg.Testnet3Params, &xeccfg.Testnet3Params},
+		{&btccfg.RegtestParams, &xeccfg.RegTestnetParams},
+	}
+
+	for _, test := range tests {
+		if !test.btc.GenesisBlockHash().IsEqual(test.fork.GenesisBlockHash()) {
+			t.Errorf("%s/%s genesis %v (want: %v)", test.fork.ChainName(), test.fork.NetName(),
+				test.fork.GenesisBlockHash(), test.btc.GenesisBlockHash())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package xeccfg
+
+import (
+	"math"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// neverActive is the activation time of the Bitcoin Cash upgrades that eCash
+// did not follow.
+const neverActive = math.MaxUint64
+
+var _ chaincfg.Params = (*Params)(nil)
+
+// Params holds the parameters of an eCash network. eCash split from Bitcoin
+// Cash at the November 2020 Axion upgrade and shares its consensus parameters
+// up to that point, so it embeds bchcfg.Params; the Bitcoin Cash upgrades
+// from May 2022 onwards are never active.
+type Params struct {
+	bchcfg.Params
+}
+
+// ChainName ...
+func (p *Params) ChainName() string {
+	return chaincfg.ChainXEC
+}
+
+// MainnetParams ...
+var MainnetParams = Params{fromBCH(bchcfg.MainnetParams, bchcfg.Params{
+	DNSSeeds: []bchcfg.DNSSeed{
+		{Host: "seed.bitcoinabc.org", HasFiltering: true},
+		{Host: "seeder.jasonbcox.com", HasFiltering: true},
+		{Host: "seeder.fabien.cash", HasFiltering: true},
+	},
+	CashAddressPrefix: "ecash",
+	HDCoinType:        899,
+})}
+
+// Testnet3Params ...
+var Testnet3Params = Params{fromBCH(bchcfg.Testnet3Params, bchcfg.Params{
+	DNSSeeds: []bchcfg.DNSSeed{
+		{Host: "testnet-seed.bitcoinabc.org", HasFiltering: true},
+	},
+	CashAddressPrefix: "ectest",
+	HDCoinType:        1,
+})}
+
+// RegTestnetParams ...
+var RegTestnetParams = Params{fromBCH(bchcfg.RegTestnetParams, bchcfg.Params{
+	DNSSeeds:          []bchcfg.DNSSeed{},
+	CashAddressPrefix: "ecregtest",
+	HDCoinType:        1,
+})}
+
+// fromBCH derives eCash parameters from the Bitcoin Cash network they split
+// from. The checkpoints after the split are dropped, the later upgrades are
+// disabled, the block size stays fixed, and the fields that differ between
+// the chains are taken from xec.
+func fromBCH(bch bchcfg.Params, xec bchcfg.Params) bchcfg.Params {
+	p := bch
+
+	p.DNSSeeds = xec.DNSSeeds
+	p.CashAddressPrefix = xec.CashAddressPrefix
+	p.HDCoinType = xec.HDCoinType
+
+	p.Upgrade8ActivationTime = neverActive
+	p.Upgrade9ActivationTime = neverActive
+	p.Upgrade10ActivationTime = neverActive
+	p.Upgrade11ActivationTime = neverActive
+	p.ABLAConfig = bchcfg.NewABLAConfig(p.DefaultExcessiveBlockSize, true)
+
+	// The ASERT anchor is the last block before the split on the networks
+	// that have one.
+	p.Checkpoints = nil
+	for _, checkpoint := range bch.Checkpoints {
+		if checkpoint.Height <= bch.ASERTAnchorHeight {
+			p.Checkpoints = append(p.Checkpoints, checkpoint)
+		}
+	}
+
+	return p
+}
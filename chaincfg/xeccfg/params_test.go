@@ -0,0 +1,52 @@
+package xeccfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+// TestForkFromBCH verifies that the eCash networks keep the Bitcoin Cash rules
+// up to Axion and nothing after it.
+func TestForkFromBCH(t *testing.T) {
+	tests := []struct {
+		xec *Params
+		bch *bchcfg.Params
+	}{
+		{&MainnetParams, &bchcfg.MainnetParams},
+		{&Testnet3Params, &bchcfg.Testnet3Params},
+		{&RegTestnetParams, &bchcfg.RegTestnetParams},
+	}
+
+	farFuture := time.Unix(1<<40, 0)
+	for _, test := range tests {
+		if errs := test.xec.Validate(); len(errs) != 0 {
+			t.Errorf("%s: Validate() = %v (want: no error)", test.xec.Name, errs)
+		}
+
+		for _, u := range []bchcfg.Upgrade{bchcfg.UpgradeAxion, bchcfg.Upgrade8, bchcfg.Upgrade11} {
+			want := u == bchcfg.UpgradeAxion
+			if got := test.xec.IsUpgradeActive(u, 0, farFuture); got != want {
+				t.Errorf("%s: IsUpgradeActive(%s) = %v (want: %v)", test.xec.Name, u, got, want)
+			}
+		}
+
+		for _, checkpoint := range test.xec.Checkpoints {
+			if checkpoint.Height > test.bch.ASERTAnchorHeight {
+				t.Errorf("%s: checkpoint at height %d is after the split", test.xec.Name, checkpoint.Height)
+			}
+		}
+
+		if test.xec.GenesisHash == nil || !test.xec.GenesisHash.IsEqual(test.bch.GenesisHash) {
+			t.Errorf("%s: genesis hash %v differs from Bitcoin Cash %v", test.xec.Name, test.xec.GenesisHash,
+				test.bch.GenesisHash)
+		}
+		if test.xec.CashAddressPrefix == test.bch.CashAddressPrefix {
+			t.Errorf("%s: cash address prefix %q is shared with Bitcoin Cash", test.xec.Name, test.xec.CashAddressPrefix)
+		}
+		if test.bch.Upgrade8ActivationTime == neverActive {
+			t.Errorf("%s: deriving eCash parameters modified bchcfg", test.bch.Name)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package pow
+
+import (
+	"math/big"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+var (
+	bigOne = big.NewInt(1)
+
+	// oneLsh256 is 1 shifted left 256 bits, used to compute the work of a target.
+	oneLsh256 = new(big.Int).Lsh(bigOne, 256)
+)
+
+// HashToBig converts a chainhash.Hash into a big.Int that can be compared
+// against a target. The hash is stored little endian, so it is reversed first.
+func HashToBig(hash *chainhash.Hash) *big.Int {
+	buf := *hash
+	blen := len(buf)
+	for i := 0; i < blen/2; i++ {
+		buf[i], buf[blen-1-i] = buf[blen-1-i], buf[i]
+	}
+
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// CompactToBig converts the compact "bits" representation of a target, as
+// found in block headers, to a big.Int.
+//
+// The compact form is an unsigned 8-bit exponent (the number of bytes of the
+// target) followed by a 23-bit mantissa and a sign bit:
+//
+//	N = (-1^sign) * mantissa * 256^(exponent-3)
+func CompactToBig(compact uint32) *big.Int {
+	mantissa := compact & 0x007fffff
+	isNegative := compact&0x00800000 != 0
+	exponent := uint(compact >> 24)
+
+	var bn *big.Int
+	if exponent <= 3 {
+		mantissa >>= 8 * (3 - exponent)
+		bn = big.NewInt(int64(mantissa))
+	} else {
+		bn = big.NewInt(int64(mantissa))
+		bn.Lsh(bn, 8*(exponent-3))
+	}
+
+	if isNegative {
+		bn = bn.Neg(bn)
+	}
+
+	return bn
+}
+
+// BigToCompact converts a big.Int to its compact "bits" representation. Only
+// the three most significant bytes of the number are kept.
+func BigToCompact(n *big.Int) uint32 {
+	if n.Sign() == 0 {
+		return 0
+	}
+
+	var mantissa uint32
+	exponent := uint(len(n.Bytes()))
+	if exponent <= 3 {
+		mantissa = uint32(n.Bits()[0])
+		mantissa <<= 8 * (3 - exponent)
+	} else {
+		tn := new(big.Int).Set(n)
+		mantissa = uint32(tn.Rsh(tn, 8*(exponent-3)).Bits()[0])
+	}
+
+	// The sign bit is part of the mantissa, so a mantissa with its high bit
+	// set must be shifted into the next exponent.
+	if mantissa&0x00800000 != 0 {
+		mantissa >>= 8
+		exponent++
+	}
+
+	compact := uint32(exponent<<24) | mantissa
+	if n.Sign() < 0 {
+		compact |= 0x00800000
+	}
+
+	return compact
+}
+
+// CalcWork returns the expected number of hashes needed to find a block
+// below the target represented by bits, that is 2^256 / (target+1).
+func CalcWork(bits uint32) *big.Int {
+	target := CompactToBig(bits)
+	if target.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+
+	denominator := new(big.Int).Add(target, bigOne)
+
+	return new(big.Int).Div(oneLsh256, denominator)
+}
@@ -0,0 +1,82 @@
+package pow
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+// TestCompactToBig verifies the decoding of the compact target representation.
+func TestCompactToBig(t *testing.T) {
+	tests := []struct {
+		in  uint32
+		out string
+	}{
+		{0x00000000, "0"},
+		{0x01003456, "0"},
+		{0x01123456, "12"},
+		{0x02123456, "1234"},
+		{0x03123456, "123456"},
+		{0x04123456, "12345600"},
+		{0x04923456, "-12345600"},
+		{0x1b04864c, "4864c000000000000000000000000000000000000000000000000"},
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000"},
+	}
+
+	for x, test := range tests {
+		want, _ := new(big.Int).SetString(test.out, 16)
+		if n := CompactToBig(test.in); n.Cmp(want) != 0 {
+			t.Errorf("CompactToBig(%d) = %x (want: %x)", x, n, want)
+		}
+	}
+}
+
+// TestBigToCompact verifies the encoding of targets to the compact representation,
+// including the normalization of mantissas that would otherwise set the sign bit.
+func TestBigToCompact(t *testing.T) {
+	tests := []struct {
+		in  string
+		out uint32
+	}{
+		{"0", 0x00000000},
+		{"12", 0x01120000},
+		{"80", 0x02008000},
+		{"-12345600", 0x04923456},
+		{"ffff0000000000000000000000000000000000000000000000000000", 0x1d00ffff},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 0x1d00ffff},
+		{"7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 0x207fffff},
+	}
+
+	for x, test := range tests {
+		n, _ := new(big.Int).SetString(test.in, 16)
+		if compact := BigToCompact(n); compact != test.out {
+			t.Errorf("BigToCompact(%d) = %08x (want: %08x)", x, compact, test.out)
+		}
+	}
+}
+
+// TestCalcWork verifies the work of the easiest mainnet target, which is the work
+// contributed by the Bitcoin genesis block.
+func TestCalcWork(t *testing.T) {
+	want := big.NewInt(0x100010001)
+	if work := CalcWork(0x1d00ffff); work.Cmp(want) != 0 {
+		t.Errorf("CalcWork = %x (want: %x)", work, want)
+	}
+
+	if work := CalcWork(0); work.Sign() != 0 {
+		t.Errorf("CalcWork(0) = %x (want: 0)", work)
+	}
+}
+
+// TestHashToBig verifies that hashes are interpreted as little endian numbers.
+// (Block #100,000: 000000000003ba27aa200b1cecaad478d2b00432346c3f1f3986da1afd33e506)
+func TestHashToBig(t *testing.T) {
+	hashStr := "000000000003ba27aa200b1cecaad478d2b00432346c3f1f3986da1afd33e506"
+	hash, _ := chainhash.NewHashFromString(hashStr)
+	want, _ := new(big.Int).SetString(hashStr, 16)
+
+	if n := HashToBig(hash); n.Cmp(want) != 0 {
+		t.Errorf("HashToBig = %x (want: %x)", n, want)
+	}
+}
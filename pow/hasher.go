@@ -0,0 +1,64 @@
+package pow
+
+import (
+	"errors"
+
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+// ErrUnknownHasher is returned by HasherByName for unknown hash functions.
+var ErrUnknownHasher = errors.New("unknown proof-of-work hash function")
+
+// Hasher computes the proof-of-work hash of a serialized block header, which
+// is compared against the target encoded in its bits.
+type Hasher interface {
+	// Name returns the name HasherByName knows the hash function by.
+	Name() string
+
+	// PowHash returns the proof-of-work hash of the serialized header.
+	PowHash(header []byte) chainhash.Hash
+}
+
+var (
+	// SHA256d is the double SHA256 used by Bitcoin and its forks, for which
+	// the proof-of-work hash is the block hash.
+	SHA256d Hasher = sha256dHasher{}
+
+	// Scrypt is scrypt with N=1024, r=1, p=1 and the header as both password
+	// and salt, as used by Litecoin and Dogecoin.
+	Scrypt Hasher = scryptHasher{}
+)
+
+// HasherByName returns the hash function with the given name.
+func HasherByName(name string) (Hasher, error) {
+	for _, hasher := range []Hasher{SHA256d, Scrypt} {
+		if hasher.Name() == name {
+			return hasher, nil
+		}
+	}
+
+	return nil, ErrUnknownHasher
+}
+
+type sha256dHasher struct{}
+
+func (sha256dHasher) Name() string {
+	return "sha256d"
+}
+
+func (sha256dHasher) PowHash(header []byte) chainhash.Hash {
+	return chainhash.SHA256dToHash(header)
+}
+
+type scryptHasher struct{}
+
+func (scryptHasher) Name() string {
+	return "scrypt"
+}
+
+func (scryptHasher) PowHash(header []byte) chainhash.Hash {
+	var hash chainhash.Hash
+	copy(hash[:], scrypt(header, header, 1024, 1, 1, chainhash.HashSize))
+
+	return hash
+}
@@ -0,0 +1,72 @@
+package pow
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// TestScrypt verifies scrypt against the test vectors of RFC 7914.
+func TestScrypt(t *testing.T) {
+	tests := []struct {
+		password, salt string
+		N, r, p        int
+		out            string
+	}{
+		{
+			"", "", 16, 1, 1,
+			"77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442" +
+				"fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906",
+		},
+		{
+			"password", "NaCl", 1024, 8, 16,
+			"fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162" +
+				"2eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640",
+		},
+	}
+
+	for x, test := range tests {
+		out := scrypt([]byte(test.password), []byte(test.salt), test.N, test.r, test.p, 64)
+		if got := hex.EncodeToString(out); got != test.out {
+			t.Errorf("scrypt #%d = %s (want: %s)", x, got, test.out)
+		}
+	}
+}
+
+// TestHashers verifies both hash functions on the Litecoin genesis header, whose
+// block hash is its SHA256d and whose proof of work is its scrypt hash.
+func TestHashers(t *testing.T) {
+	header, _ := hex.DecodeString("01000000000000000000000000000000000000000000000000000000000000000000" +
+		"0000d9ced4ed1130f7b7faad9be25323ffafa33232a17c3edf6cfd97bee6bafbdd97b9aa8e4ef0ff0f1ecd513f7c")
+
+	tests := []struct {
+		name string
+		out  string
+	}{
+		{"sha256d", "12a765e31ffd4059bada1e25190f6e98c99d9714d334efa41a195a7e7e04bfe2"},
+		{"scrypt", "0000050c34a64b415b6b15b37f2216634b5b1669cb9a2e38d76f7213b0671e00"},
+	}
+
+	for _, test := range tests {
+		hasher, err := HasherByName(test.name)
+		if err != nil {
+			t.Errorf("HasherByName(%s): unexpected error %v", test.name, err)
+			continue
+		}
+
+		if hash := hasher.PowHash(header); hash.String() != test.out {
+			t.Errorf("%s = %v (want: %s)", test.name, hash, test.out)
+		}
+	}
+
+	if _, err := HasherByName("x11"); err != ErrUnknownHasher {
+		t.Errorf("HasherByName(x11) = err %v (want: %v)", err, ErrUnknownHasher)
+	}
+}
+
+// BenchmarkScrypt measures the scrypt proof-of-work hash of a header.
+func BenchmarkScrypt(b *testing.B) {
+	header := make([]byte, 80)
+	for i := 0; i < b.N; i++ {
+		Scrypt.PowHash(header)
+	}
+}
@@ -0,0 +1,144 @@
+package pow
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+// scrypt derives a keyLen bytes key as described by RFC 7914. N must be a
+// power of two greater than one. It is only meant for proof-of-work hashing,
+// where the parameters are small, fixed and trusted.
+func scrypt(password, salt []byte, N, r, p, keyLen int) []byte {
+	blockLen := 128 * r
+	b := pbkdf2SHA256(password, salt, p*blockLen)
+
+	x := make([]uint32, 32*r)
+	v := make([]uint32, 32*r*N)
+	scratch := make([]uint32, 32*r)
+	for i := 0; i < p; i++ {
+		block := b[i*blockLen : (i+1)*blockLen]
+		for j := range x {
+			x[j] = binary.LittleEndian.Uint32(block[j*4:])
+		}
+
+		roMix(x, v, scratch, N, r)
+
+		for j, w := range x {
+			binary.LittleEndian.PutUint32(block[j*4:], w)
+		}
+	}
+
+	return pbkdf2SHA256(password, b, keyLen)
+}
+
+// pbkdf2SHA256 is PBKDF2 with HMAC-SHA256 and a single iteration, the only
+// count scrypt uses.
+func pbkdf2SHA256(password, salt []byte, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	key := make([]byte, 0, keyLen+sha256.Size)
+
+	var counter [4]byte
+	for i := uint32(1); len(key) < keyLen; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter[:])
+		key = prf.Sum(key)
+	}
+
+	return key[:keyLen]
+}
+
+// roMix is the scrypt ROMix function operating in place on x, a block of
+// 32*r words. v holds N such blocks.
+func roMix(x, v, scratch []uint32, N, r int) {
+	words := 32 * r
+
+	for i := 0; i < N; i++ {
+		copy(v[i*words:], x)
+		blockMix(x, scratch, r)
+	}
+
+	for i := 0; i < N; i++ {
+		j := int(x[words-16] & uint32(N-1))
+		for k, w := range v[j*words : (j+1)*words] {
+			x[k] ^= w
+		}
+		blockMix(x, scratch, r)
+	}
+}
+
+// blockMix is the scrypt BlockMix function operating in place on b, using y
+// as scratch space of the same size.
+func blockMix(b, y []uint32, r int) {
+	var x [16]uint32
+	copy(x[:], b[(2*r-1)*16:])
+
+	for i := 0; i < 2*r; i++ {
+		for k := range x {
+			x[k] ^= b[i*16+k]
+		}
+		salsa208(&x)
+
+		// Even blocks go to the first half of the output, odd ones to the
+		// second half.
+		dst := (i/2 + (i%2)*r) * 16
+		copy(y[dst:], x[:])
+	}
+
+	copy(b, y)
+}
+
+// salsa208 applies the Salsa20/8 core to b in place.
+func salsa208(b *[16]uint32) {
+	x := *b
+
+	for i := 0; i < 8; i += 2 {
+		x[4] ^= bits.RotateLeft32(x[0]+x[12], 7)
+		x[8] ^= bits.RotateLeft32(x[4]+x[0], 9)
+		x[12] ^= bits.RotateLeft32(x[8]+x[4], 13)
+		x[0] ^= bits.RotateLeft32(x[12]+x[8], 18)
+
+		x[9] ^= bits.RotateLeft32(x[5]+x[1], 7)
+		x[13] ^= bits.RotateLeft32(x[9]+x[5], 9)
+		x[1] ^= bits.RotateLeft32(x[13]+x[9], 13)
+		x[5] ^= bits.RotateLeft32(x[1]+x[13], 18)
+
+		x[14] ^= bits.RotateLeft32(x[10]+x[6], 7)
+		x[2] ^= bits.RotateLeft32(x[14]+x[10], 9)
+		x[6] ^= bits.RotateLeft32(x[2]+x[14], 13)
+		x[10] ^= bits.RotateLeft32(x[6]+x[2], 18)
+
+		x[3] ^= bits.RotateLeft32(x[15]+x[11], 7)
+		x[7] ^= bits.RotateLeft32(x[3]+x[15], 9)
+		x[11] ^= bits.RotateLeft32(x[7]+x[3], 13)
+		x[15] ^= bits.RotateLeft32(x[11]+x[7], 18)
+
+		x[1] ^= bits.RotateLeft32(x[0]+x[3], 7)
+		x[2] ^= bits.RotateLeft32(x[1]+x[0], 9)
+		x[3] ^= bits.RotateLeft32(x[2]+x[1], 13)
+		x[0] ^= bits.RotateLeft32(x[3]+x[2], 18)
+
+		x[6] ^= bits.RotateLeft32(x[5]+x[4], 7)
+		x[7] ^= bits.RotateLeft32(x[6]+x[5], 9)
+		x[4] ^= bits.RotateLeft32(x[7]+x[6], 13)
+		x[5] ^= bits.RotateLeft32(x[4]+x[7], 18)
+
+		x[11] ^= bits.RotateLeft32(x[10]+x[9], 7)
+		x[8] ^= bits.RotateLeft32(x[11]+x[10], 9)
+		x[9] ^= bits.RotateLeft32(x[8]+x[11], 13)
+		x[10] ^= bits.RotateLeft32(x[9]+x[8], 18)
+
+		x[12] ^= bits.RotateLeft32(x[15]+x[14], 7)
+		x[13] ^= bits.RotateLeft32(x[12]+x[15], 9)
+		x[14] ^= bits.RotateLeft32(x[13]+x[12], 13)
+		x[15] ^= bits.RotateLeft32(x[14]+x[13], 18)
+	}
+
+	for i := range b {
+		b[i] += x[i]
+	}
+}
@@ -0,0 +1,257 @@
+package versionbits
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/checksum0/go-cryptoutils/blockchain"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+	"github.com/checksum0/go-cryptoutils/chainhash"
+)
+
+const (
+	// TopBits are the bits a block version must have set in TopMask to signal
+	// BIP9 deployments.
+	TopBits = 0x20000000
+
+	// TopMask is the bitmask of the version bits reserved to identify BIP9
+	// versions.
+	TopMask = 0xe0000000
+
+	// NumBits is the number of bits available to deployments.
+	NumBits = 29
+)
+
+var (
+	// ErrUnknownDeployment ...
+	ErrUnknownDeployment = errors.New("unknown deployment")
+
+	// ErrInvalidWindow ...
+	ErrInvalidWindow = errors.New("miner confirmation window must not be zero")
+
+	// ErrInvalidBitNumber ...
+	ErrInvalidBitNumber = fmt.Errorf("deployment bit number must be below %d", NumBits)
+)
+
+// ThresholdState is the BIP9 state of a deployment for a block.
+type ThresholdState byte
+
+const (
+	// ThresholdDefined is the first state of every deployment, until the
+	// median time past reaches its start time.
+	ThresholdDefined ThresholdState = iota
+
+	// ThresholdStarted means blocks may signal the deployment.
+	ThresholdStarted
+
+	// ThresholdLockedIn means enough blocks of a window signaled and the
+	// deployment activates after the next window.
+	ThresholdLockedIn
+
+	// ThresholdActive means the rules of the deployment are enforced.
+	ThresholdActive
+
+	// ThresholdFailed means the deployment expired before locking in.
+	ThresholdFailed
+)
+
+var thresholdStateNames = []string{
+	ThresholdDefined:  "DEFINED",
+	ThresholdStarted:  "STARTED",
+	ThresholdLockedIn: "LOCKED_IN",
+	ThresholdActive:   "ACTIVE",
+	ThresholdFailed:   "FAILED",
+}
+
+// String ...
+func (s ThresholdState) String() string {
+	if int(s) < len(thresholdStateNames) {
+		return thresholdStateNames[s]
+	}
+
+	return fmt.Sprintf("ThresholdState(%d)", int(s))
+}
+
+// Checker evaluates the BIP9 deployments of a network over a chain. States are
+// cached per confirmation window, keyed by the hash of the last block of the
+// previous window, so a Checker stays valid across reorganizations of the chain
+// behind its HeaderLookup. It is safe for concurrent use.
+type Checker struct {
+	params *bchcfg.Params
+	lookup blockchain.HeaderLookup
+
+	mtx    sync.Mutex
+	caches [bchcfg.DefinedDeployments]map[chainhash.Hash]ThresholdState
+}
+
+// NewChecker returns a Checker for the deployments of params over the chain
+// served by lookup.
+func NewChecker(params *bchcfg.Params, lookup blockchain.HeaderLookup) *Checker {
+	c := &Checker{
+		params: params,
+		lookup: lookup,
+	}
+	for i := range c.caches {
+		c.caches[i] = make(map[chainhash.Hash]ThresholdState)
+	}
+
+	return c
+}
+
+// DeploymentState returns the state of a deployment for the block following the
+// block at prevHeight.
+func (c *Checker) DeploymentState(deployment int, prevHeight int32) (ThresholdState, error) {
+	if deployment < 0 || deployment >= bchcfg.DefinedDeployments {
+		return ThresholdDefined, ErrUnknownDeployment
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.thresholdState(deployment, prevHeight)
+}
+
+// NextBlockVersion returns the version a miner should use for the block
+// following the block at prevHeight, signaling every deployment that is started
+// or locked in.
+func (c *Checker) NextBlockVersion(prevHeight int32) (int32, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	version := uint32(TopBits)
+	for deployment := range c.params.Deployments {
+		state, err := c.thresholdState(deployment, prevHeight)
+		if err != nil {
+			return 0, err
+		}
+
+		if state == ThresholdStarted || state == ThresholdLockedIn {
+			version |= uint32(1) << c.params.Deployments[deployment].BitNumber
+		}
+	}
+
+	return int32(version), nil
+}
+
+// thresholdState computes the state of a deployment for the block following the
+// block at prevHeight. The state only changes at window boundaries, so it is the
+// state computed at the last block of the previous window, walking back to the
+// latest cached window and forward again. It must be called with the mutex held.
+func (c *Checker) thresholdState(deployment int, prevHeight int32) (ThresholdState, error) {
+	window := int32(c.params.MinerConfirmationWindow)
+	dep := &c.params.Deployments[deployment]
+	cache := c.caches[deployment]
+
+	// Parameters are not necessarily validated, and a zero window or a bit
+	// overlapping TopMask would divide by zero or never signal.
+	if window <= 0 {
+		return ThresholdDefined, ErrInvalidWindow
+	}
+	if dep.BitNumber >= NumBits {
+		return ThresholdDefined, ErrInvalidBitNumber
+	}
+
+	// The genesis window is always in the defined state.
+	if prevHeight+1 < window {
+		return ThresholdDefined, nil
+	}
+
+	type windowEnd struct {
+		height int32
+		hash   chainhash.Hash
+	}
+
+	var needed []windowEnd
+	state := ThresholdDefined
+	for height := prevHeight - (prevHeight+1)%window; height >= 0; height -= window {
+		header, err := c.lookup.HeaderByHeight(height)
+		if err != nil {
+			return ThresholdDefined, err
+		}
+		hash := header.BlockHash()
+
+		if cached, ok := cache[hash]; ok {
+			state = cached
+			break
+		}
+
+		medianTime, err := blockchain.CalcPastMedianTime(c.lookup, height)
+		if err != nil {
+			return ThresholdDefined, err
+		}
+
+		// Windows before the start time are always in the defined state.
+		if uint64(medianTime.Unix()) < dep.StartTime {
+			cache[hash] = ThresholdDefined
+			break
+		}
+
+		needed = append(needed, windowEnd{height, hash})
+	}
+
+	for i := len(needed) - 1; i >= 0; i-- {
+		end := needed[i]
+
+		switch state {
+		case ThresholdDefined, ThresholdStarted:
+			medianTime, err := blockchain.CalcPastMedianTime(c.lookup, end.height)
+			if err != nil {
+				return ThresholdDefined, err
+			}
+
+			if uint64(medianTime.Unix()) >= dep.ExpireTime {
+				state = ThresholdFailed
+				break
+			}
+
+			if state == ThresholdDefined {
+				if uint64(medianTime.Unix()) >= dep.StartTime {
+					state = ThresholdStarted
+				}
+				break
+			}
+
+			count, err := c.countSignals(dep.BitNumber, end.height, window)
+			if err != nil {
+				return ThresholdDefined, err
+			}
+			if count >= c.params.RuleChangeActivationThreshold {
+				state = ThresholdLockedIn
+			}
+
+		case ThresholdLockedIn:
+			state = ThresholdActive
+
+		case ThresholdActive, ThresholdFailed:
+			// Terminal states.
+		}
+
+		cache[end.hash] = state
+	}
+
+	return state, nil
+}
+
+// countSignals returns the number of blocks of the window ending at height that
+// signal the deployment bit.
+func (c *Checker) countSignals(bit uint8, height, window int32) (uint32, error) {
+	var count uint32
+	for h := height; h > height-window; h-- {
+		header, err := c.lookup.HeaderByHeight(h)
+		if err != nil {
+			return 0, err
+		}
+
+		if IsSignaling(header.Version, bit) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// IsSignaling returns whether a block version signals the deployment bit.
+func IsSignaling(version int32, bit uint8) bool {
+	return uint32(version)&TopMask == TopBits && uint32(version)&(uint32(1)<<bit) != 0
+}
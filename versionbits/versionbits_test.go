@@ -0,0 +1,165 @@
+package versionbits
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/checksum0/go-cryptoutils/blockchain"
+	"github.com/checksum0/go-cryptoutils/chaincfg/bchcfg"
+)
+
+const testStartTime = 1500000000
+
+// testChain is a HeaderLookup over headers starting at genesis.
+type testChain []*blockchain.BlockHeader
+
+func (c testChain) HeaderByHeight(height int32) (*blockchain.BlockHeader, error) {
+	if height < 0 || int(height) >= len(c) {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+
+	return c[height], nil
+}
+
+// newTestChain builds a chain of count blocks, one every 10 minutes, where the
+// blocks in [signalFrom, signalTo) set the passed version.
+func newTestChain(count int, signalFrom, signalTo int, version int32) testChain {
+	chain := make(testChain, count)
+	for i := range chain {
+		chain[i] = &blockchain.BlockHeader{
+			Version:   TopBits,
+			Timestamp: time.Unix(testStartTime+int64(i)*600, 0),
+			Bits:      0x207fffff,
+		}
+		if i >= signalFrom && i < signalTo {
+			chain[i].Version = version
+		}
+	}
+
+	return chain
+}
+
+// newTestParams returns regtest parameters with a 10 blocks confirmation window
+// requiring 8 signaling blocks. CSV starts during the second window and the test
+// dummy deployment expires during the third one.
+func newTestParams() *bchcfg.Params {
+	params := bchcfg.RegTestnetParams
+	params.MinerConfirmationWindow = 10
+	params.RuleChangeActivationThreshold = 8
+	params.Deployments[bchcfg.DeploymentCSV].StartTime = testStartTime + 6*600
+	params.Deployments[bchcfg.DeploymentTestDummy].StartTime = 0
+	params.Deployments[bchcfg.DeploymentTestDummy].ExpireTime = testStartTime + 20*600
+
+	return &params
+}
+
+// TestDeploymentState walks both deployments through their state machines.
+func TestDeploymentState(t *testing.T) {
+	params := newTestParams()
+	chain := newTestChain(60, 20, 28, TopBits|1)
+	checker := NewChecker(params, chain)
+
+	tests := []struct {
+		prevHeight int32
+		csv        ThresholdState
+		dummy      ThresholdState
+		version    int32
+	}{
+		{5, ThresholdDefined, ThresholdDefined, TopBits},
+		{9, ThresholdDefined, ThresholdStarted, TopBits | 1<<28},
+		{19, ThresholdStarted, ThresholdStarted, TopBits | 1 | 1<<28},
+		{25, ThresholdStarted, ThresholdStarted, TopBits | 1 | 1<<28},
+		{29, ThresholdLockedIn, ThresholdFailed, TopBits | 1},
+		{39, ThresholdActive, ThresholdFailed, TopBits},
+		{59, ThresholdActive, ThresholdFailed, TopBits},
+	}
+
+	// Run twice to exercise the cache.
+	for i := 0; i < 2; i++ {
+		for _, test := range tests {
+			state, err := checker.DeploymentState(bchcfg.DeploymentCSV, test.prevHeight)
+			if err != nil || state != test.csv {
+				t.Errorf("DeploymentState(CSV, %d) = %v, err %v (want: %v)", test.prevHeight, state, err, test.csv)
+			}
+
+			state, err = checker.DeploymentState(bchcfg.DeploymentTestDummy, test.prevHeight)
+			if err != nil || state != test.dummy {
+				t.Errorf("DeploymentState(TestDummy, %d) = %v, err %v (want: %v)", test.prevHeight, state, err,
+					test.dummy)
+			}
+
+			version, err := checker.NextBlockVersion(test.prevHeight)
+			if err != nil || version != test.version {
+				t.Errorf("NextBlockVersion(%d) = %08x, err %v (want: %08x)", test.prevHeight, version, err,
+					test.version)
+			}
+		}
+	}
+
+	if _, err := checker.DeploymentState(bchcfg.DefinedDeployments, 59); err != ErrUnknownDeployment {
+		t.Errorf("DeploymentState = err %v (want: %v)", err, ErrUnknownDeployment)
+	}
+}
+
+// TestDeploymentThreshold verifies that a window one signal short of the
+// threshold, or signaling without the BIP9 top bits, does not lock in.
+func TestDeploymentThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		chain   testChain
+		version int32
+		want    ThresholdState
+	}{
+		{"threshold", newTestChain(40, 20, 28, TopBits|1), TopBits | 1, ThresholdActive},
+		{"one short", newTestChain(40, 20, 27, TopBits|1), TopBits | 1, ThresholdStarted},
+		{"no top bits", newTestChain(40, 20, 30, 0x40000001), 0x40000001, ThresholdStarted},
+	}
+
+	for _, test := range tests {
+		checker := NewChecker(newTestParams(), test.chain)
+
+		state, err := checker.DeploymentState(bchcfg.DeploymentCSV, 39)
+		if err != nil || state != test.want {
+			t.Errorf("DeploymentState(%s) = %v, err %v (want: %v)", test.name, state, err, test.want)
+		}
+	}
+}
+
+// TestInvalidParams verifies that a zero confirmation window or a deployment bit
+// overlapping the top bits is reported rather than panicking.
+func TestInvalidParams(t *testing.T) {
+	chain := newTestChain(40, 0, 0, TopBits)
+
+	zeroWindow := newTestParams()
+	zeroWindow.MinerConfirmationWindow = 0
+	checker := NewChecker(zeroWindow, chain)
+	if _, err := checker.DeploymentState(bchcfg.DeploymentCSV, 39); err != ErrInvalidWindow {
+		t.Errorf("DeploymentState(zero window) = err %v (want: %v)", err, ErrInvalidWindow)
+	}
+	if _, err := checker.NextBlockVersion(39); err != ErrInvalidWindow {
+		t.Errorf("NextBlockVersion(zero window) = err %v (want: %v)", err, ErrInvalidWindow)
+	}
+
+	for _, bit := range []uint8{NumBits, 31, 255} {
+		params := newTestParams()
+		params.Deployments[bchcfg.DeploymentCSV].BitNumber = bit
+		checker := NewChecker(params, chain)
+		if _, err := checker.DeploymentState(bchcfg.DeploymentCSV, 39); err != ErrInvalidBitNumber {
+			t.Errorf("DeploymentState(bit %d) = err %v (want: %v)", bit, err, ErrInvalidBitNumber)
+		}
+		if _, err := checker.NextBlockVersion(39); err != ErrInvalidBitNumber {
+			t.Errorf("NextBlockVersion(bit %d) = err %v (want: %v)", bit, err, ErrInvalidBitNumber)
+		}
+	}
+}
+
+// TestThresholdStateString verifies state names.
+func TestThresholdStateString(t *testing.T) {
+	if s := ThresholdLockedIn.String(); s != "LOCKED_IN" {
+		t.Errorf("String = %s (want: LOCKED_IN)", s)
+	}
+	if s := ThresholdState(9).String(); s != "ThresholdState(9)" {
+		t.Errorf("String = %s (want: ThresholdState(9))", s)
+	}
+}